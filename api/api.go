@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/google/go-github/v55/github"
 
@@ -13,14 +14,58 @@ import (
 )
 
 type PRWithStatus struct {
-	PR     *github.PullRequest
-	Status string
+	PR                 *github.PullRequest
+	Status             string
+	ReviewDecision     string
+	Approvals          int
+	RequestedReviewers []string
+	RequestedTeams     []string
+	UnresolvedThreads  int
 }
 
 const pageSizeMax = 100
 
+// paginate requests pages from list until size items were collected or there
+// are no more pages. When list drops items from its pages, filtered makes it
+// always request full pages.
+func paginate[T any](size int, filtered bool, list func(page github.ListOptions) ([]T, *github.Response, error)) ([]T, error) {
+	if size <= 0 {
+		return []T{}, nil
+	}
+
+	pageSize := pageSizeMax
+	if size < pageSize && !filtered {
+		pageSize = size
+	}
+
+	var items []T
+	page := github.ListOptions{Page: 1, PerPage: pageSize}
+	for len(items) < size {
+		res, resp, err := list(page)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, res...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		page.Page = resp.NextPage
+	}
+
+	if len(items) > size {
+		items = items[:size]
+	}
+
+	return items, nil
+}
+
 // Singleton
-var githubClient *github.Client
+var (
+	githubClient      *github.Client
+	githubClientMutex sync.Mutex
+)
 
 func getAccessToken() (*oauth2.Token, error) {
 	godotenv.Load()
@@ -35,6 +80,9 @@ func getAccessToken() (*oauth2.Token, error) {
 }
 
 func getClientInstance() (*github.Client, error) {
+	githubClientMutex.Lock()
+	defer githubClientMutex.Unlock()
+
 	if githubClient == nil {
 		tokenString, err := getAccessToken()
 		if err != nil {
@@ -63,68 +111,62 @@ func parseRepoPath(repoPath string) (string, string, error) {
 	return owner, repo, nil
 }
 
-func GetOwnedRepos(username string, size int) ([]*github.Repository, error) {
+func GetOwnedRepos(username string, size int, filter RepoFilter) ([]*github.Repository, error) {
 	client, err := getClientInstance()
 	if err != nil {
 		return nil, err
 	}
 
-	page := 1
-	pageSize := pageSizeMax
-	if size < pageSize {
-		pageSize = size
-	}
-	var repos []*github.Repository
-
-	for size > 0 {
-
-		options := github.RepositoryListOptions{ListOptions: github.ListOptions{Page: page, PerPage: pageSize}}
-		res, _, err := client.Repositories.List(context.Background(), username, &options)
+	// visibility and affiliation can only be requested for the authenticated user
+	user := username
+	if filter.Visibility != "" || filter.Type != "" {
+		authenticated, _, err := client.Users.Get(context.Background(), "")
 		if err != nil {
-			msg := fmt.Errorf("could not retrieve repositories for user '%s', make sure the username is valid and GITHUB_ACCESS_TOKEN is set and valid", username)
+			msg := fmt.Errorf("could not retrieve the authenticated user, make sure GITHUB_ACCESS_TOKEN is set and valid")
 			return nil, msg
 		}
+		if strings.EqualFold(authenticated.GetLogin(), username) {
+			user = ""
+		}
+	}
 
-		repos = append(repos, res...)
-
-		size -= pageSize
-		page += 1
+	listOptions, err := filter.listOptions(user == "")
+	if err != nil {
+		return nil, err
 	}
 
-	return repos, nil
+	var serverSorted bool
+	listOptions.Sort, listOptions.Direction, serverSorted = filter.serverSort()
+
+	return listRepos(size, filter, serverSorted, func(page github.ListOptions) ([]*github.Repository, *github.Response, error) {
+		options := *listOptions
+		options.ListOptions = page
+		res, resp, err := client.Repositories.List(context.Background(), user, &options)
+		if err != nil {
+			msg := fmt.Errorf("could not retrieve repositories for user '%s', make sure the username is valid and GITHUB_ACCESS_TOKEN is set and valid", username)
+			return nil, nil, msg
+		}
+		return res, resp, nil
+	})
 }
 
-func GetFollowedRepos(username string, size int) ([]*github.Repository, error) {
+func GetFollowedRepos(username string, size int, filter RepoFilter) ([]*github.Repository, error) {
 	client, err := getClientInstance()
 	if err != nil {
 		return nil, err
 	}
 
-	page := 1
-	pageSize := pageSizeMax
-	if size < pageSize {
-		pageSize = size
-	}
-	var repos []*github.Repository
-
-	for size > 0 {
-		options := github.ListOptions{Page: page, PerPage: pageSize}
-		res, _, err := client.Activity.ListWatched(context.Background(), username, &options)
+	return listRepos(size, filter, false, func(page github.ListOptions) ([]*github.Repository, *github.Response, error) {
+		res, resp, err := client.Activity.ListWatched(context.Background(), username, &page)
 		if err != nil {
 			msg := fmt.Errorf("could not retrieve followed repositories for user '%s', make sure the username is valid and GITHUB_ACCESS_TOKEN is set and valid", username)
-			return nil, msg
+			return nil, nil, msg
 		}
-
-		repos = append(repos, res...)
-
-		size -= pageSize
-		page += 1
-	}
-
-	return repos, nil
+		return res, resp, nil
+	})
 }
 
-func ListRepoWorkflows(repoPath string) (*github.Workflows, error) {
+func ListRepoWorkflows(repoPath string, size int) (*github.Workflows, error) {
 	client, err := getClientInstance()
 	if err != nil {
 		return nil, err
@@ -135,10 +177,19 @@ func ListRepoWorkflows(repoPath string) (*github.Workflows, error) {
 		return nil, err
 	}
 
-	workflows, _, err := client.Actions.ListWorkflows(context.Background(), owner, repo, nil)
+	workflows := &github.Workflows{}
+	workflows.Workflows, err = paginate(size, false, func(page github.ListOptions) ([]*github.Workflow, *github.Response, error) {
+		res, resp, err := client.Actions.ListWorkflows(context.Background(), owner, repo, &page)
+		if err != nil {
+			msg := fmt.Errorf("could not retrieve workflows for repo '%s', make sure the repository exists and GITHUB_ACCESS_TOKEN is set and valid", repoPath)
+			return nil, nil, msg
+		}
+
+		workflows.TotalCount = res.TotalCount
+		return res.Workflows, resp, nil
+	})
 	if err != nil {
-		msg := fmt.Errorf("could not retrieve workflows for repo '%s', make sure the repository exists and GITHUB_ACCESS_TOKEN is set and valid", repoPath)
-		return nil, msg
+		return nil, err
 	}
 
 	return workflows, nil
@@ -155,29 +206,16 @@ func ListPRsByRepo(repoPath string, size int) ([]*github.PullRequest, error) {
 		return nil, err
 	}
 
-	page := 1
-	pageSize := pageSizeMax
-	if size < pageSize {
-		pageSize = size
-	}
-	var prs []*github.PullRequest
-
-	for size > 0 {
-
-		options := github.PullRequestListOptions{State: "open", Sort: "created", Direction: "desc", ListOptions: github.ListOptions{Page: page, PerPage: pageSize}}
-		res, _, err := client.PullRequests.List(context.Background(), owner, repo, &options)
+	return paginate(size, false, func(page github.ListOptions) ([]*github.PullRequest, *github.Response, error) {
+		options := github.PullRequestListOptions{State: "open", Sort: "created", Direction: "desc", ListOptions: page}
+		res, resp, err := client.PullRequests.List(context.Background(), owner, repo, &options)
 		if err != nil {
 			msg := fmt.Errorf("could not retrieve pull requests for repo '%s', make sure the repository exists and GITHUB_ACCESS_TOKEN is set and valid", repoPath)
-			return nil, msg
+			return nil, nil, msg
 		}
 
-		prs = append(prs, res...)
-
-		size -= pageSize
-		page += 1
-	}
-
-	return prs, nil
+		return res, resp, nil
+	})
 }
 
 func GetPRStatus(repoPath string, pr *github.PullRequest) (string, error) {
@@ -228,27 +266,23 @@ func ListPRsByAuthor(author string, size int) ([]*github.Issue, error) {
 		return nil, err
 	}
 
-	page := 1
-	pageSize := pageSizeMax
-	if size < pageSize {
-		pageSize = size
+	prs, err := searchPRs(client, fmt.Sprintf("is:pr author:%s", author), "created", size)
+	if err != nil {
+		msg := fmt.Errorf("could not retrieve pull requests for author '%s', make sure the username is valid and GITHUB_ACCESS_TOKEN is set and valid", author)
+		return nil, msg
 	}
-	var prs []*github.Issue
 
-	for size > 0 {
+	return prs, nil
+}
 
-		options := &github.SearchOptions{Sort: "created", Order: "desc", ListOptions: github.ListOptions{Page: page, PerPage: pageSize}}
-		res, _, err := client.Search.Issues(context.Background(), fmt.Sprintf("is:pr author:%s", author), options)
+func searchPRs(client *github.Client, query string, sort string, size int) ([]*github.Issue, error) {
+	return paginate(size, false, func(page github.ListOptions) ([]*github.Issue, *github.Response, error) {
+		options := &github.SearchOptions{Sort: sort, Order: "desc", ListOptions: page}
+		res, resp, err := client.Search.Issues(context.Background(), query, options)
 		if err != nil {
-			msg := fmt.Errorf("could not retrieve pull requests for author '%s', make sure the username is valid and GITHUB_ACCESS_TOKEN is set and valid", author)
-			return nil, msg
+			return nil, nil, err
 		}
 
-		prs = append(prs, res.Issues...)
-
-		size -= pageSize
-		page += 1
-	}
-
-	return prs, nil
+		return res.Issues, resp, nil
+	})
 }
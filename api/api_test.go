@@ -138,8 +138,62 @@ func TestParseRepoWithValidPath(t *testing.T) {
 	}
 }
 
+func TestPaginateStopsAtSize(t *testing.T) {
+	var requested []github.ListOptions
+	items, err := paginate(5, false, func(page github.ListOptions) ([]int, *github.Response, error) {
+		requested = append(requested, page)
+		return []int{1, 2, 3, 4, 5, 6}, &github.Response{NextPage: page.Page + 1}, nil
+	})
+
+	if err != nil {
+		t.Errorf(expectedNoError, err.Error())
+	}
+
+	if len(items) != 5 {
+		t.Errorf("expected 5 items, but got %d", len(items))
+	}
+
+	if len(requested) != 1 || requested[0].PerPage != 5 {
+		t.Errorf("expected a single page of 5, but got %v", requested)
+	}
+}
+
+func TestPaginateWithNegativeSize(t *testing.T) {
+	items, err := paginate(-1, false, func(page github.ListOptions) ([]int, *github.Response, error) {
+		t.Error("expected no page to be requested")
+		return []int{1}, &github.Response{}, nil
+	})
+
+	if err != nil {
+		t.Errorf(expectedNoError, err.Error())
+	}
+
+	if len(items) != 0 {
+		t.Errorf("expected no items, but got %v", items)
+	}
+}
+
+func TestPaginateFollowsNextPage(t *testing.T) {
+	var requested []int
+	items, err := paginate(10, true, func(page github.ListOptions) ([]int, *github.Response, error) {
+		requested = append(requested, page.Page)
+		if page.Page == 3 {
+			return []int{page.Page}, &github.Response{}, nil
+		}
+		return []int{page.Page}, &github.Response{NextPage: page.Page + 1}, nil
+	})
+
+	if err != nil {
+		t.Errorf(expectedNoError, err.Error())
+	}
+
+	if len(items) != 3 || len(requested) != 3 {
+		t.Errorf("expected 3 pages, but got %v", requested)
+	}
+}
+
 func TestGetOwnedReposWithInvalidUsername(t *testing.T) {
-	_, err := GetOwnedRepos("gidhjfgu90w45u", 30)
+	_, err := GetOwnedRepos("gidhjfgu90w45u", 30, RepoFilter{})
 
 	expectedError := "could not retrieve repositories for user 'gidhjfgu90w45u', make sure the username is valid and GITHUB_ACCESS_TOKEN is set and valid"
 	if err == nil {
@@ -151,7 +205,7 @@ func TestGetOwnedReposWithInvalidUsername(t *testing.T) {
 
 func TestGetOwnedReposWithValidUsername(t *testing.T) {
 	expectedName := "carolinafsilva"
-	repos, err := GetOwnedRepos(expectedName, 30)
+	repos, err := GetOwnedRepos(expectedName, 30, RepoFilter{})
 
 	if err != nil {
 		t.Errorf(expectedNoError, err.Error())
@@ -179,7 +233,7 @@ func TestGetOwnedReposWithValidUsername(t *testing.T) {
 }
 
 func TestGetFollowedReposWithInvalidUsername(t *testing.T) {
-	_, err := GetFollowedRepos("gidhjfgu90w45u", 30)
+	_, err := GetFollowedRepos("gidhjfgu90w45u", 30, RepoFilter{})
 
 	expectedError := "could not retrieve followed repositories for user 'gidhjfgu90w45u', make sure the username is valid and GITHUB_ACCESS_TOKEN is set and valid"
 	if err == nil {
@@ -191,7 +245,7 @@ func TestGetFollowedReposWithInvalidUsername(t *testing.T) {
 
 func TestGetFollowedReposWithValidUsername(t *testing.T) {
 	expectedName := "carolinafsilva"
-	repos, err := GetFollowedRepos(expectedName, 30)
+	repos, err := GetFollowedRepos(expectedName, 30, RepoFilter{})
 
 	if err != nil {
 		t.Errorf(expectedNoError, err.Error())
@@ -213,7 +267,7 @@ func TestGetFollowedReposWithValidUsername(t *testing.T) {
 }
 
 func TestListRepoWorkflowsWithInvalidRepoPath(t *testing.T) {
-	_, err := ListRepoWorkflows("notavalidpath")
+	_, err := ListRepoWorkflows("notavalidpath", 30)
 
 	expectedError := "invalid repo path 'notavalidpath'"
 	if err == nil {
@@ -224,7 +278,7 @@ func TestListRepoWorkflowsWithInvalidRepoPath(t *testing.T) {
 }
 
 func TestListRepoWorkflowsWithInvalidOwner(t *testing.T) {
-	_, err := ListRepoWorkflows("gidhjfgu90w45u/repo")
+	_, err := ListRepoWorkflows("gidhjfgu90w45u/repo", 30)
 
 	expectedError := "could not retrieve workflows for repo 'gidhjfgu90w45u/repo', make sure the repository exists and GITHUB_ACCESS_TOKEN is set and valid"
 	if err == nil {
@@ -235,7 +289,7 @@ func TestListRepoWorkflowsWithInvalidOwner(t *testing.T) {
 }
 
 func TestListRepoWorkflowsWithInvalidRepo(t *testing.T) {
-	_, err := ListRepoWorkflows("carolinafsilva/repo")
+	_, err := ListRepoWorkflows("carolinafsilva/repo", 30)
 
 	expectedError := "could not retrieve workflows for repo 'carolinafsilva/repo', make sure the repository exists and GITHUB_ACCESS_TOKEN is set and valid"
 	if err == nil {
@@ -247,7 +301,7 @@ func TestListRepoWorkflowsWithInvalidRepo(t *testing.T) {
 
 func TestListRepoWorkflowsWithValidRepoPath(t *testing.T) {
 	repoPath := "aleph-two/flowcar.pt"
-	workflows, err := ListRepoWorkflows(repoPath)
+	workflows, err := ListRepoWorkflows(repoPath, 30)
 
 	if err != nil {
 		t.Errorf(expectedNoError, err.Error())
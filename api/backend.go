@@ -0,0 +1,249 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+	"github.com/joho/godotenv"
+)
+
+const (
+	BackendREST    = "rest"
+	BackendGraphQL = "graphql"
+)
+
+type PRListOptions struct {
+	Status  bool
+	Reviews bool
+}
+
+// pullRequestsQuery only asks for the CI state and the reviews when $status
+// and $reviews are set, like the REST backend.
+const pullRequestsQuery = `query($owner: String!, $repo: String!, $first: Int!, $cursor: String, $status: Boolean!, $reviews: Boolean!) {
+  repository(owner: $owner, name: $repo) {
+    pullRequests(states: OPEN, first: $first, after: $cursor, orderBy: {field: CREATED_AT, direction: DESC}) {
+      pageInfo { hasNextPage endCursor }
+      nodes {
+        ...reviewFields @include(if: $reviews)
+        number
+        title
+        url
+        createdAt
+        headRefOid
+        author { login }
+        labels(first: 20) { nodes { name } }
+        reviewRequests(first: 20) {
+          nodes { requestedReviewer { ... on User { login } ... on Team { slug } } }
+        }
+        commits(last: 1) @include(if: $status) {
+          nodes { commit { statusCheckRollup { state } } }
+        }
+      }
+    }
+  }
+}
+` + reviewFieldsFragment
+
+type pullRequestNode struct {
+	reviewFields
+	Title      string    `json:"title"`
+	URL        string    `json:"url"`
+	CreatedAt  time.Time `json:"createdAt"`
+	HeadRefOid string    `json:"headRefOid"`
+	Author     struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	Labels struct {
+		Nodes []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	} `json:"labels"`
+	ReviewRequests struct {
+		Nodes []struct {
+			RequestedReviewer struct {
+				Login string `json:"login"`
+				Slug  string `json:"slug"`
+			} `json:"requestedReviewer"`
+		} `json:"nodes"`
+	} `json:"reviewRequests"`
+	Commits struct {
+		Nodes []struct {
+			Commit struct {
+				StatusCheckRollup *struct {
+					State string `json:"state"`
+				} `json:"statusCheckRollup"`
+			} `json:"commit"`
+		} `json:"nodes"`
+	} `json:"commits"`
+}
+
+type pullRequestsData struct {
+	Repository struct {
+		PullRequests struct {
+			PageInfo struct {
+				HasNextPage bool   `json:"hasNextPage"`
+				EndCursor   string `json:"endCursor"`
+			} `json:"pageInfo"`
+			Nodes []*pullRequestNode `json:"nodes"`
+		} `json:"pullRequests"`
+	} `json:"repository"`
+}
+
+func getBackend() (string, error) {
+	godotenv.Load()
+	backend, isSet := os.LookupEnv("GG_API_BACKEND")
+	if !isSet || backend == "" {
+		return BackendREST, nil
+	}
+
+	backend = strings.ToLower(backend)
+	if backend != BackendREST && backend != BackendGraphQL {
+		return "", fmt.Errorf("invalid backend '%s', GG_API_BACKEND must be '%s' or '%s'", backend, BackendREST, BackendGraphQL)
+	}
+
+	return backend, nil
+}
+
+func ListPRsByRepoWithDetails(repoPath string, size int, backend string, options PRListOptions) ([]*PRWithStatus, error) {
+	if backend == "" {
+		var err error
+		backend, err = getBackend()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch backend {
+	case BackendGraphQL:
+		prs, err := listPRsByRepoGraphQL(repoPath, size, options)
+		if err == nil || !isSchemaError(err) {
+			return prs, err
+		}
+		// the server's GraphQL schema is missing fields, use REST instead
+	case BackendREST:
+	default:
+		return nil, fmt.Errorf("invalid backend '%s', use '%s' or '%s'", backend, BackendREST, BackendGraphQL)
+	}
+
+	return listPRsByRepoREST(repoPath, size, options)
+}
+
+func listPRsByRepoREST(repoPath string, size int, options PRListOptions) ([]*PRWithStatus, error) {
+	var prs []*PRWithStatus
+
+	if options.Status {
+		var err error
+		prs, err = ListPRsByRepoWithStatus(repoPath, size)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		plainPRs, err := ListPRsByRepo(repoPath, size)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pr := range plainPRs {
+			prs = append(prs, &PRWithStatus{PR: pr})
+		}
+	}
+
+	if options.Reviews {
+		err := AddPRReviews(repoPath, prs)
+		if isSchemaError(err) {
+			// the server's GraphQL schema is missing the review fields
+			err = addPRReviewsREST(repoPath, prs)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return prs, nil
+}
+
+func listPRsByRepoGraphQL(repoPath string, size int, options PRListOptions) ([]*PRWithStatus, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var prs []*PRWithStatus
+	variables := map[string]interface{}{"owner": owner, "repo": repo, "status": options.Status, "reviews": options.Reviews}
+
+	for size > 0 {
+		first := pageSizeMax
+		if size < first {
+			first = size
+		}
+		variables["first"] = first
+
+		var data pullRequestsData
+		if err := graphqlQuery(client, pullRequestsQuery, variables, &data); err != nil {
+			if isSchemaError(err) {
+				return nil, err
+			}
+			msg := fmt.Errorf("could not retrieve pull requests for repo '%s', make sure the repository exists and GITHUB_ACCESS_TOKEN is set and valid", repoPath)
+			return nil, msg
+		}
+
+		pullRequests := data.Repository.PullRequests
+		for _, node := range pullRequests.Nodes {
+			prs = append(prs, node.toPRWithStatus(options))
+		}
+
+		size -= first
+		if !pullRequests.PageInfo.HasNextPage {
+			break
+		}
+		variables["cursor"] = pullRequests.PageInfo.EndCursor
+	}
+
+	return prs, nil
+}
+
+func (node *pullRequestNode) toPRWithStatus(options PRListOptions) *PRWithStatus {
+	pr := &github.PullRequest{
+		Number:    github.Int(node.Number),
+		Title:     github.String(node.Title),
+		HTMLURL:   github.String(node.URL),
+		CreatedAt: &github.Timestamp{Time: node.CreatedAt},
+		User:      &github.User{Login: github.String(node.Author.Login)},
+		Head:      &github.PullRequestBranch{SHA: github.String(node.HeadRefOid)},
+	}
+
+	for _, label := range node.Labels.Nodes {
+		pr.Labels = append(pr.Labels, &github.Label{Name: github.String(label.Name)})
+	}
+
+	for _, request := range node.ReviewRequests.Nodes {
+		reviewer := request.RequestedReviewer
+		if reviewer.Slug != "" {
+			pr.RequestedTeams = append(pr.RequestedTeams, &github.Team{Slug: github.String(reviewer.Slug)})
+		} else {
+			pr.RequestedReviewers = append(pr.RequestedReviewers, &github.User{Login: github.String(reviewer.Login)})
+		}
+	}
+
+	prWithStatus := &PRWithStatus{PR: pr}
+	if options.Status {
+		// an unset rollup means no checks reported yet, which REST reports as pending
+		prWithStatus.Status = "pending"
+		if len(node.Commits.Nodes) > 0 && node.Commits.Nodes[0].Commit.StatusCheckRollup != nil {
+			prWithStatus.Status = strings.ToLower(node.Commits.Nodes[0].Commit.StatusCheckRollup.State)
+		}
+	}
+	if options.Reviews {
+		applyReviewFields(prWithStatus, &node.reviewFields)
+	}
+
+	return prWithStatus
+}
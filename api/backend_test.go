@@ -0,0 +1,101 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestGetBackendWithInvalidBackend(t *testing.T) {
+	backend, isSet := os.LookupEnv("GG_API_BACKEND")
+	os.Setenv("GG_API_BACKEND", "soap")
+
+	_, err := getBackend()
+
+	expectedError := "invalid backend 'soap', GG_API_BACKEND must be 'rest' or 'graphql'"
+	if err == nil {
+		t.Error(expectedErrorGotNil)
+	} else if err.Error() != expectedError {
+		t.Errorf(expectedDifferentError, expectedError, err.Error())
+	}
+
+	t.Cleanup(func() {
+		if isSet {
+			os.Setenv("GG_API_BACKEND", backend)
+		} else {
+			os.Unsetenv("GG_API_BACKEND")
+		}
+	})
+}
+
+func TestListPRsByRepoWithDetailsWithInvalidBackend(t *testing.T) {
+	_, err := ListPRsByRepoWithDetails("owner/repo", 30, "soap", PRListOptions{})
+
+	expectedError := "invalid backend 'soap', use 'rest' or 'graphql'"
+	if err == nil {
+		t.Error(expectedErrorGotNil)
+	} else if err.Error() != expectedError {
+		t.Errorf(expectedDifferentError, expectedError, err.Error())
+	}
+}
+
+func TestIsSchemaError(t *testing.T) {
+	schemaErr := &GraphQLError{Message: "Field 'statusCheckRollup' doesn't exist on type 'Commit'", Code: "undefinedField"}
+
+	if !isSchemaError(fmt.Errorf("wrapped: %w", schemaErr)) {
+		t.Error("expected an undefined field to be a schema error")
+	}
+
+	if isSchemaError(&GraphQLError{Message: "Could not resolve to a Repository"}) {
+		t.Error("expected a missing repository not to be a schema error")
+	}
+}
+
+func TestPullRequestNodeToPRWithStatus(t *testing.T) {
+	raw := `{"number": 7, "title": "Add feature", "createdAt": "2023-10-01T12:00:00Z", "headRefOid": "abc",
+		"author": {"login": "alice"}, "reviewDecision": "APPROVED",
+		"labels": {"nodes": [{"name": "enhancement"}]},
+		"reviewRequests": {"nodes": [{"requestedReviewer": {"login": "bob"}}, {"requestedReviewer": {"slug": "core"}}]},
+		"latestOpinionatedReviews": {"nodes": [{"state": "APPROVED", "author": {"login": "carol"}}]},
+		"reviewThreads": {"nodes": []},
+		"commits": {"nodes": [{"commit": {"statusCheckRollup": {"state": "SUCCESS"}}}]}}`
+
+	var node pullRequestNode
+	if err := json.Unmarshal([]byte(raw), &node); err != nil {
+		t.Fatalf(expectedNoError, err.Error())
+	}
+
+	pr := node.toPRWithStatus(PRListOptions{Status: true, Reviews: true})
+
+	if pr.PR.GetNumber() != 7 || pr.PR.GetTitle() != "Add feature" || pr.PR.GetHead().GetSHA() != "abc" {
+		t.Errorf("expected pull request #7 'Add feature' at abc, but got #%d '%s' at %s", pr.PR.GetNumber(), pr.PR.GetTitle(), pr.PR.GetHead().GetSHA())
+	}
+
+	if pr.Status != "success" {
+		t.Errorf("expected status to be 'success', but got '%s'", pr.Status)
+	}
+
+	if pr.ReviewDecision != "approved" || pr.Approvals != 1 {
+		t.Errorf("expected an approved pull request with 1 approval, but got '%s' with %d", pr.ReviewDecision, pr.Approvals)
+	}
+
+	if len(pr.RequestedReviewers) != 1 || len(pr.RequestedTeams) != 1 || len(pr.PR.Labels) != 1 {
+		t.Errorf("expected one requested reviewer, team and label, but got %v, %v and %d labels", pr.RequestedReviewers, pr.RequestedTeams, len(pr.PR.Labels))
+	}
+}
+
+func TestPullRequestNodeToPRWithStatusWithoutOptions(t *testing.T) {
+	raw := `{"number": 7, "title": "Add feature", "reviewDecision": "APPROVED"}`
+
+	var node pullRequestNode
+	if err := json.Unmarshal([]byte(raw), &node); err != nil {
+		t.Fatalf(expectedNoError, err.Error())
+	}
+
+	pr := node.toPRWithStatus(PRListOptions{})
+
+	if pr.PR.GetNumber() != 7 || pr.Status != "" || pr.ReviewDecision != "" {
+		t.Errorf("expected pull request #7 without status or reviews, but got #%d '%s' '%s'", pr.PR.GetNumber(), pr.Status, pr.ReviewDecision)
+	}
+}
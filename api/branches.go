@@ -0,0 +1,279 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+)
+
+type BranchInfo struct {
+	Name        string
+	Default     bool
+	Protected   bool
+	SHA         string
+	Author      string
+	Message     string
+	CommittedAt time.Time
+	Ahead       int
+	Behind      int
+}
+
+const defaultBranchQuery = `query($owner: String!, $repo: String!) {
+  repository(owner: $owner, name: $repo) {
+    defaultBranchRef { name }
+  }
+}`
+
+// branchesQuery compares each branch, as the base, with the default branch,
+// so aheadBy counts the commits the branch is behind and behindBy the ones it
+// is ahead.
+const branchesQuery = `query($owner: String!, $repo: String!, $default: String!, $cursor: String) {
+  repository(owner: $owner, name: $repo) {
+    refs(refPrefix: "refs/heads/", first: 100, after: $cursor) {
+      pageInfo { hasNextPage endCursor }
+      nodes {
+        name
+        branchProtectionRule { pattern }
+        compare(headRef: $default) { aheadBy behindBy }
+        target {
+          ... on Commit {
+            oid
+            committedDate
+            messageHeadline
+            author { name user { login } }
+          }
+        }
+      }
+    }
+  }
+}`
+
+type defaultBranchData struct {
+	Repository struct {
+		DefaultBranchRef struct {
+			Name string `json:"name"`
+		} `json:"defaultBranchRef"`
+	} `json:"repository"`
+}
+
+type branchesData struct {
+	Repository struct {
+		Refs struct {
+			PageInfo struct {
+				HasNextPage bool   `json:"hasNextPage"`
+				EndCursor   string `json:"endCursor"`
+			} `json:"pageInfo"`
+			Nodes []struct {
+				Name                 string `json:"name"`
+				BranchProtectionRule *struct {
+					Pattern string `json:"pattern"`
+				} `json:"branchProtectionRule"`
+				Compare struct {
+					AheadBy  int `json:"aheadBy"`
+					BehindBy int `json:"behindBy"`
+				} `json:"compare"`
+				Target struct {
+					OID             string    `json:"oid"`
+					CommittedDate   time.Time `json:"committedDate"`
+					MessageHeadline string    `json:"messageHeadline"`
+					Author          struct {
+						Name string `json:"name"`
+						User *struct {
+							Login string `json:"login"`
+						} `json:"user"`
+					} `json:"author"`
+				} `json:"target"`
+			} `json:"nodes"`
+		} `json:"refs"`
+	} `json:"repository"`
+}
+
+func ListBranches(repoPath string) ([]*BranchInfo, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := fmt.Errorf("could not retrieve branches for repo '%s', make sure the repository exists and GITHUB_ACCESS_TOKEN is set and valid", repoPath)
+
+	var defaultData defaultBranchData
+	variables := map[string]interface{}{"owner": owner, "repo": repo}
+	if err := graphqlQuery(client, defaultBranchQuery, variables, &defaultData); err != nil {
+		return nil, msg
+	}
+	defaultBranch := defaultData.Repository.DefaultBranchRef.Name
+	variables["default"] = defaultBranch
+
+	var branches []*BranchInfo
+	for {
+		var data branchesData
+		if err := graphqlQuery(client, branchesQuery, variables, &data); err != nil {
+			return nil, msg
+		}
+
+		refs := data.Repository.Refs
+		for _, ref := range refs.Nodes {
+			author := ref.Target.Author.Name
+			if ref.Target.Author.User != nil {
+				author = ref.Target.Author.User.Login
+			}
+
+			branches = append(branches, &BranchInfo{
+				Name:        ref.Name,
+				Default:     ref.Name == defaultBranch,
+				Protected:   ref.BranchProtectionRule != nil,
+				SHA:         ref.Target.OID,
+				Author:      author,
+				Message:     ref.Target.MessageHeadline,
+				CommittedAt: ref.Target.CommittedDate,
+				Ahead:       ref.Compare.BehindBy,
+				Behind:      ref.Compare.AheadBy,
+			})
+		}
+
+		if !refs.PageInfo.HasNextPage {
+			break
+		}
+		variables["cursor"] = refs.PageInfo.EndCursor
+	}
+
+	// branches can also be protected by rulesets, which only REST reports per branch
+	rulesets, _, err := client.Repositories.GetAllRulesets(context.Background(), owner, repo, true)
+	if err != nil && !isNotFound(err) {
+		return nil, msg
+	}
+	if len(rulesets) > 0 {
+		for _, branch := range branches {
+			if branch.Protected {
+				continue
+			}
+
+			rules, _, err := client.Repositories.GetRulesForBranch(context.Background(), owner, repo, branch.Name)
+			if err != nil {
+				return nil, msg
+			}
+			branch.Protected = len(rules) > 0
+		}
+	}
+
+	sortBranches(branches)
+
+	return branches, nil
+}
+
+// sortBranches puts the default branch first and the others from the most
+// recently committed to.
+func sortBranches(branches []*BranchInfo) {
+	sort.SliceStable(branches, func(i, j int) bool {
+		if branches[i].Default != branches[j].Default {
+			return branches[i].Default
+		}
+		return branches[i].CommittedAt.After(branches[j].CommittedAt)
+	})
+}
+
+// FilterBranches selects the branches that can be cleaned up: those without
+// commits missing from the default branch when merged is set, and those last
+// committed to more than olderThan ago when it is not zero. The default
+// branch and protected branches are never selected.
+func FilterBranches(branches []*BranchInfo, merged bool, olderThan time.Duration, now time.Time) []*BranchInfo {
+	var selected []*BranchInfo
+	for _, branch := range branches {
+		if branch.Default || branch.Protected {
+			continue
+		}
+		if merged && branch.Ahead > 0 {
+			continue
+		}
+		if olderThan > 0 && now.Sub(branch.CommittedAt) < olderThan {
+			continue
+		}
+		selected = append(selected, branch)
+	}
+
+	return selected
+}
+
+func PlanBranchDelete(repoPath string, branches []string) ([]*Mutation, error) {
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var mutations []*Mutation
+	for _, branch := range branches {
+		ref := "heads/" + branch
+		mutations = append(mutations, &Mutation{
+			Method: "DELETE",
+			Path:   fmt.Sprintf("/repos/%s/%s/git/refs/%s", owner, repo, ref),
+			apply: func(ctx context.Context, client *github.Client) error {
+				_, err := client.Git.DeleteRef(ctx, owner, repo, ref)
+				return err
+			},
+		})
+	}
+
+	return mutations, nil
+}
+
+func PlanBranchRename(repoPath string, branch string, name string) ([]*Mutation, error) {
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	mutation := &Mutation{
+		Method: "POST",
+		Path:   fmt.Sprintf("/repos/%s/%s/branches/%s/rename", owner, repo, url.PathEscape(branch)),
+		Body:   map[string]string{"new_name": name},
+		apply: func(ctx context.Context, client *github.Client) error {
+			_, _, err := client.Repositories.RenameBranch(ctx, owner, repo, branch, name)
+			return err
+		},
+	}
+
+	return []*Mutation{mutation}, nil
+}
+
+func CompareRefs(repoPath string, base string, head string) (*github.CommitsComparison, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// the commits of a comparison are paginated, the rest is the same on every page
+	var comparison *github.CommitsComparison
+	commits, err := paginate(math.MaxInt, false, func(page github.ListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
+		res, resp, err := client.Repositories.CompareCommits(context.Background(), owner, repo, base, head, &page)
+		if err != nil {
+			msg := fmt.Errorf("could not compare '%s' and '%s' in repo '%s', make sure both refs exist and GITHUB_ACCESS_TOKEN is set and valid", base, head, repoPath)
+			return nil, nil, msg
+		}
+
+		if comparison == nil {
+			comparison = res
+		}
+		return res.Commits, resp, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	comparison.Commits = commits
+
+	return comparison, nil
+}
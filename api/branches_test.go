@@ -0,0 +1,71 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFilterBranches(t *testing.T) {
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	branches := []*BranchInfo{
+		{Name: "main", Default: true, CommittedAt: now.Add(-200 * 24 * time.Hour)},
+		{Name: "release", Protected: true, CommittedAt: now.Add(-200 * 24 * time.Hour)},
+		{Name: "merged-old", CommittedAt: now.Add(-100 * 24 * time.Hour)},
+		{Name: "merged-new", CommittedAt: now.Add(-time.Hour)},
+		{Name: "open-old", Ahead: 3, CommittedAt: now.Add(-100 * 24 * time.Hour)},
+	}
+
+	filters := []struct {
+		merged    bool
+		olderThan time.Duration
+		expected  []string
+	}{
+		{true, 0, []string{"merged-old", "merged-new"}},
+		{false, 90 * 24 * time.Hour, []string{"merged-old", "open-old"}},
+		{true, 90 * 24 * time.Hour, []string{"merged-old"}},
+	}
+
+	for _, f := range filters {
+		selected := FilterBranches(branches, f.merged, f.olderThan, now)
+		if len(selected) != len(f.expected) {
+			t.Errorf("expected %v for merged %t and older than %s, got %d branches", f.expected, f.merged, f.olderThan, len(selected))
+			continue
+		}
+
+		for i, branch := range selected {
+			if branch.Name != f.expected[i] {
+				t.Errorf(expectedDifferentError, f.expected[i], branch.Name)
+			}
+		}
+	}
+}
+
+func TestSortBranches(t *testing.T) {
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	branches := []*BranchInfo{
+		{Name: "old", CommittedAt: now.Add(-48 * time.Hour)},
+		{Name: "main", Default: true, CommittedAt: now.Add(-72 * time.Hour)},
+		{Name: "new", CommittedAt: now},
+	}
+
+	sortBranches(branches)
+
+	expected := []string{"main", "new", "old"}
+	for i, branch := range branches {
+		if branch.Name != expected[i] {
+			t.Errorf(expectedDifferentError, expected[i], branch.Name)
+		}
+	}
+}
+
+func TestPlanBranchRename(t *testing.T) {
+	mutations, err := PlanBranchRename("acme/api", "master", "main")
+	if err != nil {
+		t.Fatalf(expectedNoError, err.Error())
+	}
+
+	expected := `POST /repos/acme/api/branches/master/rename {"new_name":"main"}`
+	if len(mutations) != 1 || mutations[0].String() != expected {
+		t.Errorf("expected mutation '%s', but got %v", expected, mutations)
+	}
+}
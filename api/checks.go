@@ -0,0 +1,134 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+)
+
+type Check struct {
+	Name        string
+	State       string
+	StartedAt   time.Time
+	CompletedAt time.Time
+	URL         string
+}
+
+func (c *Check) Done() bool {
+	return c.State != "pending"
+}
+
+func (c *Check) Failed() bool {
+	switch c.State {
+	case "failure", "error", "cancelled", "timed_out", "action_required", "startup_failure", "stale":
+		return true
+	}
+
+	return false
+}
+
+func (c *Check) Duration() time.Duration {
+	if c.StartedAt.IsZero() {
+		return 0
+	}
+
+	end := c.CompletedAt
+	if end.IsZero() {
+		end = time.Now()
+	}
+
+	return end.Sub(c.StartedAt).Round(time.Second)
+}
+
+func ListPRChecks(repoPath string, number int) ([]*Check, error) {
+	pr, err := GetPR(repoPath, number)
+	if err != nil {
+		return nil, err
+	}
+
+	return ListChecksForRef(repoPath, pr.GetHead().GetSHA())
+}
+
+func ListChecksForRef(repoPath string, ref string) ([]*Check, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := fmt.Errorf("could not retrieve checks for '%s' in '%s', make sure the repository exists and GITHUB_ACCESS_TOKEN is set and valid", ref, repoPath)
+
+	checkRuns, err := paginate(math.MaxInt, false, func(page github.ListOptions) ([]*github.CheckRun, *github.Response, error) {
+		options := github.ListCheckRunsOptions{ListOptions: page}
+		res, resp, err := client.Checks.ListCheckRunsForRef(context.Background(), owner, repo, ref, &options)
+		if err != nil {
+			return nil, nil, msg
+		}
+
+		return res.CheckRuns, resp, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := paginate(math.MaxInt, false, func(page github.ListOptions) ([]*github.RepoStatus, *github.Response, error) {
+		res, resp, err := client.Repositories.GetCombinedStatus(context.Background(), owner, repo, ref, &page)
+		if err != nil {
+			return nil, nil, msg
+		}
+
+		return res.Statuses, resp, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeChecks(checkRuns, statuses), nil
+}
+
+func mergeChecks(checkRuns []*github.CheckRun, statuses []*github.RepoStatus) []*Check {
+	var checks []*Check
+
+	for _, run := range checkRuns {
+		state := run.GetConclusion()
+		if run.GetStatus() != "completed" {
+			state = "pending"
+		}
+
+		checks = append(checks, &Check{
+			Name:        run.GetName(),
+			State:       state,
+			StartedAt:   run.GetStartedAt().Time,
+			CompletedAt: run.GetCompletedAt().Time,
+			URL:         run.GetHTMLURL(),
+		})
+	}
+
+	for _, status := range statuses {
+		check := &Check{
+			Name:      status.GetContext(),
+			State:     status.GetState(),
+			StartedAt: status.GetCreatedAt().Time,
+			URL:       status.GetTargetURL(),
+		}
+		if check.Done() {
+			check.CompletedAt = status.GetUpdatedAt().Time
+		}
+
+		checks = append(checks, check)
+	}
+
+	sort.SliceStable(checks, func(i, j int) bool {
+		return checks[i].Name < checks[j].Name
+	})
+
+	return checks
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+)
+
+func TestMergeChecks(t *testing.T) {
+	start := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	checkRuns := []*github.CheckRun{
+		{Name: github.String("test"), Status: github.String("in_progress"), StartedAt: &github.Timestamp{Time: start}},
+		{Name: github.String("build"), Status: github.String("completed"), Conclusion: github.String("success"), StartedAt: &github.Timestamp{Time: start}, CompletedAt: &github.Timestamp{Time: start.Add(90 * time.Second)}},
+	}
+	statuses := []*github.RepoStatus{
+		{Context: github.String("ci/legacy"), State: github.String("failure"), CreatedAt: &github.Timestamp{Time: start}, UpdatedAt: &github.Timestamp{Time: start.Add(time.Minute)}},
+	}
+
+	checks := mergeChecks(checkRuns, statuses)
+
+	expectedNames := []string{"build", "ci/legacy", "test"}
+	if len(checks) != len(expectedNames) {
+		t.Fatalf("expected %d checks, but got %d", len(expectedNames), len(checks))
+	}
+
+	for i, check := range checks {
+		if check.Name != expectedNames[i] {
+			t.Errorf("expected check %d to be '%s', but got '%s'", i, expectedNames[i], check.Name)
+		}
+	}
+
+	if checks[0].Duration() != 90*time.Second {
+		t.Errorf("expected build to take 1m30s, but got %s", checks[0].Duration())
+	}
+
+	if !checks[1].Failed() {
+		t.Error("expected ci/legacy to have failed")
+	}
+
+	if checks[2].Done() {
+		t.Error("expected test to be pending")
+	}
+}
@@ -0,0 +1,233 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+)
+
+type PRComment struct {
+	ID        int64
+	Author    string
+	Body      string
+	CreatedAt time.Time
+	URL       string
+	Review    bool
+	Path      string
+	Line      int
+	Outdated  bool
+	Resolved  bool
+	Replies   []*PRComment
+}
+
+type reviewThreadState struct {
+	Resolved bool
+	Outdated bool
+}
+
+const reviewThreadsQuery = `query($owner: String!, $repo: String!, $number: Int!, $cursor: String) {
+  repository(owner: $owner, name: $repo) {
+    pullRequest(number: $number) {
+      reviewThreads(first: 100, after: $cursor) {
+        pageInfo { hasNextPage endCursor }
+        nodes {
+          isResolved
+          isOutdated
+          comments(first: 1) { nodes { databaseId } }
+        }
+      }
+    }
+  }
+}`
+
+type reviewThreadsData struct {
+	Repository struct {
+		PullRequest struct {
+			ReviewThreads struct {
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+				Nodes []struct {
+					IsResolved bool `json:"isResolved"`
+					IsOutdated bool `json:"isOutdated"`
+					Comments   struct {
+						Nodes []struct {
+							DatabaseID int64 `json:"databaseId"`
+						} `json:"nodes"`
+					} `json:"comments"`
+				} `json:"nodes"`
+			} `json:"reviewThreads"`
+		} `json:"pullRequest"`
+	} `json:"repository"`
+}
+
+func CreatePRComment(repoPath string, number int, body string) (*github.IssueComment, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == "" {
+		return nil, fmt.Errorf("comment body cannot be empty")
+	}
+
+	comment, _, err := client.Issues.CreateComment(context.Background(), owner, repo, number, &github.IssueComment{Body: github.String(body)})
+	if err != nil {
+		msg := fmt.Errorf("could not comment on pull request #%d in '%s', make sure the pull request exists and GITHUB_ACCESS_TOKEN is set and valid", number, repoPath)
+		return nil, msg
+	}
+
+	return comment, nil
+}
+
+func ListPRComments(repoPath string, number int) ([]*PRComment, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := fmt.Errorf("could not retrieve comments for pull request #%d in '%s', make sure the pull request exists and GITHUB_ACCESS_TOKEN is set and valid", number, repoPath)
+
+	issueComments, err := paginate(math.MaxInt, false, func(page github.ListOptions) ([]*github.IssueComment, *github.Response, error) {
+		options := github.IssueListCommentsOptions{ListOptions: page}
+		res, resp, err := client.Issues.ListComments(context.Background(), owner, repo, number, &options)
+		if err != nil {
+			return nil, nil, msg
+		}
+
+		return res, resp, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reviewComments, err := paginate(math.MaxInt, false, func(page github.ListOptions) ([]*github.PullRequestComment, *github.Response, error) {
+		options := github.PullRequestListCommentsOptions{ListOptions: page}
+		res, resp, err := client.PullRequests.ListComments(context.Background(), owner, repo, number, &options)
+		if err != nil {
+			return nil, nil, msg
+		}
+
+		return res, resp, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	threads, err := getReviewThreadStates(client, owner, repo, number)
+	if err != nil {
+		return nil, msg
+	}
+
+	return buildConversation(issueComments, reviewComments, threads), nil
+}
+
+func getReviewThreadStates(client *github.Client, owner string, repo string, number int) (map[int64]reviewThreadState, error) {
+	threads := make(map[int64]reviewThreadState)
+	variables := map[string]interface{}{"owner": owner, "repo": repo, "number": number}
+
+	for {
+		var data reviewThreadsData
+		if err := graphqlQuery(client, reviewThreadsQuery, variables, &data); err != nil {
+			return nil, err
+		}
+
+		reviewThreads := data.Repository.PullRequest.ReviewThreads
+		for _, thread := range reviewThreads.Nodes {
+			if len(thread.Comments.Nodes) == 0 {
+				continue
+			}
+			threads[thread.Comments.Nodes[0].DatabaseID] = reviewThreadState{Resolved: thread.IsResolved, Outdated: thread.IsOutdated}
+		}
+
+		if !reviewThreads.PageInfo.HasNextPage {
+			break
+		}
+		variables["cursor"] = reviewThreads.PageInfo.EndCursor
+	}
+
+	return threads, nil
+}
+
+func buildConversation(issueComments []*github.IssueComment, reviewComments []*github.PullRequestComment, threads map[int64]reviewThreadState) []*PRComment {
+	var conversation []*PRComment
+
+	for _, comment := range issueComments {
+		conversation = append(conversation, &PRComment{
+			ID:        comment.GetID(),
+			Author:    comment.GetUser().GetLogin(),
+			Body:      comment.GetBody(),
+			CreatedAt: comment.GetCreatedAt().Time,
+			URL:       comment.GetHTMLURL(),
+		})
+	}
+
+	byID := make(map[int64]*PRComment)
+	var replies []*github.PullRequestComment
+	for _, comment := range reviewComments {
+		line := comment.GetLine()
+		if line == 0 {
+			line = comment.GetOriginalLine()
+		}
+
+		prComment := &PRComment{
+			ID:        comment.GetID(),
+			Author:    comment.GetUser().GetLogin(),
+			Body:      comment.GetBody(),
+			CreatedAt: comment.GetCreatedAt().Time,
+			URL:       comment.GetHTMLURL(),
+			Review:    true,
+			Path:      comment.GetPath(),
+			Line:      line,
+			Outdated:  comment.Position == nil,
+		}
+		byID[prComment.ID] = prComment
+
+		if comment.InReplyTo != nil {
+			replies = append(replies, comment)
+			continue
+		}
+
+		if state, ok := threads[prComment.ID]; ok {
+			prComment.Resolved = state.Resolved
+			prComment.Outdated = state.Outdated
+		}
+		conversation = append(conversation, prComment)
+	}
+
+	for _, reply := range replies {
+		parent, ok := byID[reply.GetInReplyTo()]
+		if !ok {
+			// the thread root was deleted, show the reply on its own
+			conversation = append(conversation, byID[reply.GetID()])
+			continue
+		}
+		parent.Replies = append(parent.Replies, byID[reply.GetID()])
+	}
+
+	sort.SliceStable(conversation, func(i, j int) bool {
+		return conversation[i].CreatedAt.Before(conversation[j].CreatedAt)
+	})
+	for _, comment := range conversation {
+		sort.SliceStable(comment.Replies, func(i, j int) bool {
+			return comment.Replies[i].CreatedAt.Before(comment.Replies[j].CreatedAt)
+		})
+	}
+
+	return conversation
+}
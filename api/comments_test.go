@@ -0,0 +1,63 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+)
+
+func TestBuildConversationSortsAndThreadsComments(t *testing.T) {
+	start := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	issueComments := []*github.IssueComment{
+		{ID: github.Int64(1), Body: github.String("second"), CreatedAt: &github.Timestamp{Time: start.Add(2 * time.Hour)}},
+	}
+	reviewComments := []*github.PullRequestComment{
+		{ID: github.Int64(10), Body: github.String("first"), Position: github.Int(3), CreatedAt: &github.Timestamp{Time: start}},
+		{ID: github.Int64(11), InReplyTo: github.Int64(10), Body: github.String("reply"), CreatedAt: &github.Timestamp{Time: start.Add(3 * time.Hour)}},
+		{ID: github.Int64(12), Body: github.String("third"), CreatedAt: &github.Timestamp{Time: start.Add(4 * time.Hour)}},
+	}
+	threads := map[int64]reviewThreadState{10: {Resolved: true}}
+
+	conversation := buildConversation(issueComments, reviewComments, threads)
+
+	if len(conversation) != 3 {
+		t.Fatalf("expected 3 top-level comments, but got %d", len(conversation))
+	}
+
+	expectedBodies := []string{"first", "second", "third"}
+	for i, comment := range conversation {
+		if comment.Body != expectedBodies[i] {
+			t.Errorf("expected comment %d to be '%s', but got '%s'", i, expectedBodies[i], comment.Body)
+		}
+	}
+
+	if !conversation[0].Resolved {
+		t.Error("expected the first thread to be resolved")
+	}
+
+	if len(conversation[0].Replies) != 1 || conversation[0].Replies[0].Body != "reply" {
+		t.Error("expected the reply to be threaded under the first comment")
+	}
+
+	if !conversation[2].Outdated {
+		t.Error("expected a review comment without position to be outdated")
+	}
+}
+
+func TestGraphqlURL(t *testing.T) {
+	client := github.NewClient(nil)
+
+	expectedURL := "https://api.github.com/graphql"
+	if url := graphqlURL(client); url != expectedURL {
+		t.Errorf("expected graphql URL to be %s, but got %s", expectedURL, url)
+	}
+
+	client, _ = client.WithEnterpriseURLs("https://ghes.example.com/api/v3/", "https://ghes.example.com/api/uploads/")
+
+	expectedURL = "https://ghes.example.com/api/graphql"
+	if url := graphqlURL(client); url != expectedURL {
+		t.Errorf("expected graphql URL to be %s, but got %s", expectedURL, url)
+	}
+}
@@ -0,0 +1,224 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+)
+
+type DashboardPR struct {
+	RepoPath       string
+	Number         int
+	Title          string
+	UpdatedAt      time.Time
+	Status         string
+	ReviewDecision string
+}
+
+type Dashboard struct {
+	User            string
+	Authored        []*DashboardPR
+	ReviewRequested []*DashboardPR
+	Assigned        []*DashboardPR
+}
+
+func GetPRDashboard(size int) (*Dashboard, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	user, _, err := client.Users.Get(context.Background(), "")
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve the authenticated user, make sure GITHUB_ACCESS_TOKEN is set and valid")
+	}
+
+	dashboard := &Dashboard{User: user.GetLogin()}
+	sections := []struct {
+		query string
+		prs   *[]*DashboardPR
+	}{
+		{"is:pr is:open author:@me", &dashboard.Authored},
+		{"is:pr is:open review-requested:@me", &dashboard.ReviewRequested},
+		{"is:pr is:open assignee:@me", &dashboard.Assigned},
+	}
+
+	for _, section := range sections {
+		issues, err := searchPRs(client, section.query, "updated", size)
+		if err != nil {
+			return nil, fmt.Errorf("could not search pull requests for '%s', make sure GITHUB_ACCESS_TOKEN is set and valid", dashboard.User)
+		}
+
+		prs, err := getDashboardPRs(client, issues)
+		if isSchemaError(err) {
+			// the server's GraphQL schema is missing fields, use REST instead
+			prs, err = nil, nil
+			for _, issue := range issues {
+				pr, err := getDashboardPR(client, issue)
+				if err != nil {
+					return nil, err
+				}
+				prs = append(prs, pr)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		*section.prs = prs
+	}
+
+	return dashboard, nil
+}
+
+type dashboardData map[string]*struct {
+	PullRequest *pullRequestNode `json:"pullRequest"`
+}
+
+// getDashboardPRs fetches the CI state and the reviews of pull requests from
+// any repository in a single query per batch, the way AddPRReviews does for
+// the pull requests of one repository.
+func getDashboardPRs(client *github.Client, issues []*github.Issue) ([]*DashboardPR, error) {
+	var prs []*DashboardPR
+
+	for start := 0; start < len(issues); start += reviewsBatchSize {
+		end := start + reviewsBatchSize
+		if end > len(issues) {
+			end = len(issues)
+		}
+		batch := issues[start:end]
+
+		var data dashboardData
+		if err := graphqlQuery(client, dashboardQuery(batch), nil, &data); err != nil {
+			if isSchemaError(err) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("could not retrieve the pull requests of the dashboard, make sure GITHUB_ACCESS_TOKEN is set and valid")
+		}
+
+		for i, issue := range batch {
+			repoPath := repoPathFromURL(issue.GetRepositoryURL())
+			repository := data[fmt.Sprintf("pr%d", i)]
+			if repository == nil || repository.PullRequest == nil {
+				return nil, fmt.Errorf("could not retrieve pull request #%d in '%s', make sure the pull request exists and GITHUB_ACCESS_TOKEN is set and valid", issue.GetNumber(), repoPath)
+			}
+
+			pr := repository.PullRequest.toPRWithStatus(PRListOptions{Status: true, Reviews: true})
+			prs = append(prs, &DashboardPR{
+				RepoPath:       repoPath,
+				Number:         issue.GetNumber(),
+				Title:          issue.GetTitle(),
+				UpdatedAt:      issue.GetUpdatedAt().Time,
+				Status:         pr.Status,
+				ReviewDecision: pr.ReviewDecision,
+			})
+		}
+	}
+
+	return prs, nil
+}
+
+func dashboardQuery(issues []*github.Issue) string {
+	var query strings.Builder
+
+	query.WriteString("query {\n")
+	for i, issue := range issues {
+		owner, repo, _ := strings.Cut(repoPathFromURL(issue.GetRepositoryURL()), "/")
+		query.WriteString(fmt.Sprintf("  pr%d: repository(owner: %s, name: %s) {\n", i, strconv.Quote(owner), strconv.Quote(repo)))
+		query.WriteString(fmt.Sprintf("    pullRequest(number: %d) {\n", issue.GetNumber()))
+		query.WriteString("      ...reviewFields\n")
+		query.WriteString("      reviewRequests(first: 20) { nodes { requestedReviewer { ... on User { login } ... on Team { slug } } } }\n")
+		query.WriteString("      commits(last: 1) { nodes { commit { statusCheckRollup { state } } } }\n")
+		query.WriteString("    }\n  }\n")
+	}
+	query.WriteString("}\n")
+	query.WriteString(reviewFieldsFragment)
+
+	return query.String()
+}
+
+func getDashboardPR(client *github.Client, issue *github.Issue) (*DashboardPR, error) {
+	repoPath := repoPathFromURL(issue.GetRepositoryURL())
+	number := issue.GetNumber()
+
+	pr, err := GetPR(repoPath, number)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := GetPRStatus(repoPath, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	decision, err := getReviewDecision(client, repoPath, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DashboardPR{
+		RepoPath:       repoPath,
+		Number:         number,
+		Title:          issue.GetTitle(),
+		UpdatedAt:      issue.GetUpdatedAt().Time,
+		Status:         status,
+		ReviewDecision: decision,
+	}, nil
+}
+
+func getReviewDecision(client *github.Client, repoPath string, pr *github.PullRequest) (string, error) {
+	reviews, err := listPRReviews(client, repoPath, pr.GetNumber())
+	if err != nil {
+		return "", err
+	}
+
+	pending := len(pr.RequestedReviewers) + len(pr.RequestedTeams)
+
+	return reviewDecision(reviews, pending), nil
+}
+
+// reviewDecision mirrors GitHub's review decision using each reviewer's latest
+// approving or blocking review.
+func reviewDecision(reviews []*github.PullRequestReview, pendingReviewers int) string {
+	approvals := 0
+	for _, state := range latestReviews(reviews) {
+		if state == "CHANGES_REQUESTED" {
+			return "changes_requested"
+		}
+		if state == "APPROVED" {
+			approvals++
+		}
+	}
+
+	if approvals > 0 && pendingReviewers == 0 {
+		return "approved"
+	}
+
+	return "review_required"
+}
+
+// latestReviews maps each reviewer to the state of their latest approving,
+// blocking or dismissed review.
+func latestReviews(reviews []*github.PullRequestReview) map[string]string {
+	latest := make(map[string]string)
+	for _, review := range reviews {
+		state := review.GetState()
+		if state == "APPROVED" || state == "CHANGES_REQUESTED" || state == "DISMISSED" {
+			latest[review.GetUser().GetLogin()] = state
+		}
+	}
+
+	return latest
+}
+
+func repoPathFromURL(repositoryURL string) string {
+	path := strings.Split(strings.TrimSuffix(repositoryURL, "/"), "/")
+	if len(path) < 2 {
+		return repositoryURL
+	}
+
+	return path[len(path)-2] + "/" + path[len(path)-1]
+}
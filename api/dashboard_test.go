@@ -0,0 +1,67 @@
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v55/github"
+)
+
+func newReview(login string, state string) *github.PullRequestReview {
+	return &github.PullRequestReview{User: &github.User{Login: github.String(login)}, State: github.String(state)}
+}
+
+func TestReviewDecisionWithApprovals(t *testing.T) {
+	reviews := []*github.PullRequestReview{
+		newReview("alice", "CHANGES_REQUESTED"),
+		newReview("alice", "COMMENTED"),
+		newReview("alice", "APPROVED"),
+		newReview("bob", "APPROVED"),
+	}
+
+	if decision := reviewDecision(reviews, 0); decision != "approved" {
+		t.Errorf("expected decision to be 'approved', but got '%s'", decision)
+	}
+
+	if decision := reviewDecision(reviews, 1); decision != "review_required" {
+		t.Errorf("expected decision to be 'review_required', but got '%s'", decision)
+	}
+}
+
+func TestReviewDecisionWithChangesRequested(t *testing.T) {
+	reviews := []*github.PullRequestReview{
+		newReview("alice", "APPROVED"),
+		newReview("bob", "CHANGES_REQUESTED"),
+	}
+
+	if decision := reviewDecision(reviews, 0); decision != "changes_requested" {
+		t.Errorf("expected decision to be 'changes_requested', but got '%s'", decision)
+	}
+}
+
+func TestRepoPathFromURL(t *testing.T) {
+	repoPath := repoPathFromURL("https://api.github.com/repos/owner/repo")
+
+	if repoPath != "owner/repo" {
+		t.Errorf("expected repo path to be 'owner/repo', but got '%s'", repoPath)
+	}
+}
+
+func TestDashboardQuery(t *testing.T) {
+	issues := []*github.Issue{
+		{Number: github.Int(7), RepositoryURL: github.String("https://api.github.com/repos/acme/api")},
+		{Number: github.Int(9), RepositoryURL: github.String("https://api.github.com/repos/acme/web")},
+	}
+
+	query := dashboardQuery(issues)
+
+	for _, expected := range []string{
+		"pr0: repository(owner: \"acme\", name: \"api\") {\n    pullRequest(number: 7) {",
+		"pr1: repository(owner: \"acme\", name: \"web\") {\n    pullRequest(number: 9) {",
+		"fragment reviewFields on PullRequest",
+	} {
+		if !strings.Contains(query, expected) {
+			t.Errorf("expected the query to contain '%s', but got '%s'", expected, query)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-github/v55/github"
+)
+
+type PREdit struct {
+	Title           *string
+	Body            *string
+	Base            *string
+	AddLabels       []string
+	RemoveLabels    []string
+	AddAssignees    []string
+	RemoveAssignees []string
+	AddReviewers    []string
+	RemoveReviewers []string
+	Milestone       *int
+	Draft           *bool
+}
+
+const convertToDraftMutation = `mutation($id: ID!) {
+  convertPullRequestToDraft(input: {pullRequestId: $id}) { clientMutationId }
+}`
+
+const markReadyForReviewMutation = `mutation($id: ID!) {
+  markPullRequestReadyForReview(input: {pullRequestId: $id}) { clientMutationId }
+}`
+
+func GetPR(repoPath string, number int) (*github.PullRequest, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, _, err := client.PullRequests.Get(context.Background(), owner, repo, number)
+	if err != nil {
+		msg := fmt.Errorf("could not retrieve pull request #%d in '%s', make sure the pull request exists and GITHUB_ACCESS_TOKEN is set and valid", number, repoPath)
+		return nil, msg
+	}
+
+	return pr, nil
+}
+
+func PlanPREdit(repoPath string, number int, edit *PREdit) ([]*Mutation, error) {
+	pr, err := GetPR(repoPath, number)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return planPREdit(owner, repo, pr, edit), nil
+}
+
+func PlanPRStateChange(repoPath string, number int, state string) ([]*Mutation, error) {
+	if state != "open" && state != "closed" {
+		return nil, fmt.Errorf("invalid pull request state '%s'", state)
+	}
+
+	pr, err := GetPR(repoPath, number)
+	if err != nil {
+		return nil, err
+	}
+
+	if pr.GetState() == state {
+		return nil, nil
+	}
+
+	if pr.GetMerged() {
+		return nil, fmt.Errorf("pull request #%d in '%s' is already merged", number, repoPath)
+	}
+
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	update := &github.PullRequest{State: github.String(state)}
+	mutation := &Mutation{
+		Method: "PATCH",
+		Path:   fmt.Sprintf("/repos/%s/%s/pulls/%d", owner, repo, number),
+		Body:   map[string]string{"state": state},
+		apply: func(ctx context.Context, client *github.Client) error {
+			_, _, err := client.PullRequests.Edit(ctx, owner, repo, number, update)
+			return err
+		},
+	}
+
+	return []*Mutation{mutation}, nil
+}
+
+func planPREdit(owner string, repo string, pr *github.PullRequest, edit *PREdit) []*Mutation {
+	var mutations []*Mutation
+	number := pr.GetNumber()
+	pullPath := fmt.Sprintf("/repos/%s/%s/pulls/%d", owner, repo, number)
+	issuePath := fmt.Sprintf("/repos/%s/%s/issues/%d", owner, repo, number)
+
+	update := &github.PullRequest{}
+	fields := make(map[string]string)
+	if edit.Title != nil {
+		update.Title = edit.Title
+		fields["title"] = *edit.Title
+	}
+	if edit.Body != nil {
+		update.Body = edit.Body
+		fields["body"] = *edit.Body
+	}
+	if edit.Base != nil {
+		update.Base = &github.PullRequestBranch{Ref: edit.Base}
+		fields["base"] = *edit.Base
+	}
+	if len(fields) > 0 {
+		mutations = append(mutations, &Mutation{
+			Method: "PATCH",
+			Path:   pullPath,
+			Body:   fields,
+			apply: func(ctx context.Context, client *github.Client) error {
+				_, _, err := client.PullRequests.Edit(ctx, owner, repo, number, update)
+				return err
+			},
+		})
+	}
+
+	if len(edit.AddLabels) > 0 {
+		labels := edit.AddLabels
+		mutations = append(mutations, &Mutation{
+			Method: "POST",
+			Path:   issuePath + "/labels",
+			Body:   map[string][]string{"labels": labels},
+			apply: func(ctx context.Context, client *github.Client) error {
+				_, _, err := client.Issues.AddLabelsToIssue(ctx, owner, repo, number, labels)
+				return err
+			},
+		})
+	}
+
+	for _, label := range edit.RemoveLabels {
+		label := label
+		mutations = append(mutations, &Mutation{
+			Method: "DELETE",
+			Path:   issuePath + "/labels/" + url.PathEscape(label),
+			apply: func(ctx context.Context, client *github.Client) error {
+				_, err := client.Issues.RemoveLabelForIssue(ctx, owner, repo, number, label)
+				return err
+			},
+		})
+	}
+
+	if len(edit.AddAssignees) > 0 {
+		assignees := edit.AddAssignees
+		mutations = append(mutations, &Mutation{
+			Method: "POST",
+			Path:   issuePath + "/assignees",
+			Body:   map[string][]string{"assignees": assignees},
+			apply: func(ctx context.Context, client *github.Client) error {
+				_, _, err := client.Issues.AddAssignees(ctx, owner, repo, number, assignees)
+				return err
+			},
+		})
+	}
+
+	if len(edit.RemoveAssignees) > 0 {
+		assignees := edit.RemoveAssignees
+		mutations = append(mutations, &Mutation{
+			Method: "DELETE",
+			Path:   issuePath + "/assignees",
+			Body:   map[string][]string{"assignees": assignees},
+			apply: func(ctx context.Context, client *github.Client) error {
+				_, _, err := client.Issues.RemoveAssignees(ctx, owner, repo, number, assignees)
+				return err
+			},
+		})
+	}
+
+	if len(edit.AddReviewers) > 0 {
+		reviewers := splitReviewers(edit.AddReviewers)
+		mutations = append(mutations, &Mutation{
+			Method: "POST",
+			Path:   pullPath + "/requested_reviewers",
+			Body:   reviewers,
+			apply: func(ctx context.Context, client *github.Client) error {
+				_, _, err := client.PullRequests.RequestReviewers(ctx, owner, repo, number, reviewers)
+				return err
+			},
+		})
+	}
+
+	if len(edit.RemoveReviewers) > 0 {
+		reviewers := splitReviewers(edit.RemoveReviewers)
+		mutations = append(mutations, &Mutation{
+			Method: "DELETE",
+			Path:   pullPath + "/requested_reviewers",
+			Body:   reviewers,
+			apply: func(ctx context.Context, client *github.Client) error {
+				_, err := client.PullRequests.RemoveReviewers(ctx, owner, repo, number, reviewers)
+				return err
+			},
+		})
+	}
+
+	if edit.Milestone != nil {
+		milestone := *edit.Milestone
+		if milestone == 0 {
+			mutations = append(mutations, &Mutation{
+				Method: "PATCH",
+				Path:   issuePath,
+				Body:   map[string]interface{}{"milestone": nil},
+				apply: func(ctx context.Context, client *github.Client) error {
+					_, _, err := client.Issues.RemoveMilestone(ctx, owner, repo, number)
+					return err
+				},
+			})
+		} else {
+			mutations = append(mutations, &Mutation{
+				Method: "PATCH",
+				Path:   issuePath,
+				Body:   map[string]int{"milestone": milestone},
+				apply: func(ctx context.Context, client *github.Client) error {
+					_, _, err := client.Issues.Edit(ctx, owner, repo, number, &github.IssueRequest{Milestone: &milestone})
+					return err
+				},
+			})
+		}
+	}
+
+	if edit.Draft != nil && *edit.Draft != pr.GetDraft() {
+		query := markReadyForReviewMutation
+		if *edit.Draft {
+			query = convertToDraftMutation
+		}
+		variables := map[string]interface{}{"id": pr.GetNodeID()}
+		mutations = append(mutations, &Mutation{
+			Method: "POST",
+			Path:   "/graphql",
+			Body:   graphqlRequest{Query: query, Variables: variables},
+			apply: func(ctx context.Context, client *github.Client) error {
+				var data interface{}
+				return graphqlQuery(client, query, variables, &data)
+			},
+		})
+	}
+
+	return mutations
+}
+
+func splitReviewers(reviewers []string) github.ReviewersRequest {
+	var request github.ReviewersRequest
+
+	for _, reviewer := range reviewers {
+		// teams are given as org/team-slug, users as their login
+		if _, team, found := strings.Cut(reviewer, "/"); found {
+			request.TeamReviewers = append(request.TeamReviewers, team)
+		} else {
+			request.Reviewers = append(request.Reviewers, reviewer)
+		}
+	}
+
+	return request
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v55/github"
+)
+
+func TestPlanPREditWithNoChanges(t *testing.T) {
+	pr := &github.PullRequest{Number: github.Int(1)}
+
+	mutations := planPREdit("owner", "repo", pr, &PREdit{})
+
+	if len(mutations) != 0 {
+		t.Errorf("expected no mutations, but got %d", len(mutations))
+	}
+}
+
+func TestPlanPREditWithChanges(t *testing.T) {
+	pr := &github.PullRequest{Number: github.Int(1), Draft: github.Bool(true), NodeID: github.String("PR_1")}
+	title := "New title"
+	ready := false
+
+	edit := &PREdit{
+		Title:        &title,
+		AddLabels:    []string{"bug"},
+		RemoveLabels: []string{"needs triage"},
+		AddReviewers: []string{"octocat", "org/team"},
+		Draft:        &ready,
+	}
+
+	mutations := planPREdit("owner", "repo", pr, edit)
+
+	expectedMutations := []string{
+		`PATCH /repos/owner/repo/pulls/1 {"title":"New title"}`,
+		`POST /repos/owner/repo/issues/1/labels {"labels":["bug"]}`,
+		`DELETE /repos/owner/repo/issues/1/labels/needs%20triage`,
+		`POST /repos/owner/repo/pulls/1/requested_reviewers {"reviewers":["octocat"],"team_reviewers":["team"]}`,
+	}
+
+	if len(mutations) != len(expectedMutations)+1 {
+		t.Fatalf("expected %d mutations, but got %d", len(expectedMutations)+1, len(mutations))
+	}
+
+	for i, expected := range expectedMutations {
+		if mutations[i].String() != expected {
+			t.Errorf("expected mutation '%s', but got '%s'", expected, mutations[i].String())
+		}
+	}
+
+	if mutations[4].Path != "/graphql" {
+		t.Errorf("expected the draft conversion to use graphql, but got '%s'", mutations[4].Path)
+	}
+}
+
+func TestPlanPRStateChangeWithInvalidState(t *testing.T) {
+	_, err := PlanPRStateChange("owner/repo", 1, "merged")
+
+	expectedError := "invalid pull request state 'merged'"
+	if err == nil {
+		t.Error(expectedErrorGotNil)
+	} else if err.Error() != expectedError {
+		t.Errorf(expectedDifferentError, expectedError, err.Error())
+	}
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/google/go-github/v55/github"
+)
+
+type FlakyJob struct {
+	Workflow string
+	Job      string
+	Commits  int
+	Flakes   int
+	URLs     []string
+}
+
+func (f *FlakyJob) Rate() float64 {
+	if f.Commits == 0 {
+		return 0
+	}
+
+	return float64(f.Flakes) / float64(f.Commits)
+}
+
+// flakyKey groups on the workflow ID, since run-name can give every run of a
+// workflow its own name.
+type flakyKey struct {
+	workflow int64
+	job      string
+}
+
+type commitKey struct {
+	flakyKey
+	sha string
+}
+
+func GetFlakyJobs(repoPath string, size int) ([]*FlakyJob, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	runs, err := ListRuns(repoPath, RunFilter{Status: "completed"}, size)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make(map[int64][]*github.WorkflowJob)
+	for _, run := range runs {
+		runJobs, err := listRunJobs(client, owner, repo, run.GetID(), "all")
+		if err != nil {
+			msg := fmt.Errorf("could not retrieve jobs for workflow run %d in '%s', make sure the run exists and GITHUB_ACCESS_TOKEN is set and valid", run.GetID(), repoPath)
+			return nil, msg
+		}
+		jobs[run.GetID()] = runJobs
+	}
+
+	workflows, err := ListRepoWorkflows(repoPath, math.MaxInt)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[int64]string)
+	for _, workflow := range workflows.Workflows {
+		names[workflow.GetID()] = workflow.GetName()
+	}
+
+	return detectFlakyJobs(runs, jobs, names), nil
+}
+
+// detectFlakyJobs groups the attempts of each job by commit, across re-runs and
+// separate runs of the same workflow, and counts a flake for every commit where
+// the job failed and then passed. Workflows are shown by their name in names,
+// or by the name of one of their runs when they are missing from it.
+func detectFlakyJobs(runs []*github.WorkflowRun, jobs map[int64][]*github.WorkflowJob, names map[int64]string) []*FlakyJob {
+	attempts := make(map[commitKey][]*github.WorkflowJob)
+	for _, run := range runs {
+		if _, ok := names[run.GetWorkflowID()]; !ok {
+			names[run.GetWorkflowID()] = run.GetName()
+		}
+		for _, job := range jobs[run.GetID()] {
+			key := commitKey{flakyKey{workflow: run.GetWorkflowID(), job: job.GetName()}, run.GetHeadSHA()}
+			attempts[key] = append(attempts[key], job)
+		}
+	}
+
+	flaky := make(map[flakyKey]*FlakyJob)
+	for key, commitJobs := range attempts {
+		job, ok := flaky[key.flakyKey]
+		if !ok {
+			job = &FlakyJob{Workflow: names[key.workflow], Job: key.job}
+			flaky[key.flakyKey] = job
+		}
+		job.Commits++
+
+		sort.SliceStable(commitJobs, func(i, j int) bool {
+			return commitJobs[i].GetCompletedAt().Before(commitJobs[j].GetCompletedAt().Time)
+		})
+
+		var failed *github.WorkflowJob
+		for _, attempt := range commitJobs {
+			if attempt.GetConclusion() == "failure" || attempt.GetConclusion() == "timed_out" {
+				if failed == nil {
+					failed = attempt
+				}
+			} else if attempt.GetConclusion() == "success" && failed != nil {
+				job.Flakes++
+				job.URLs = append(job.URLs, failed.GetHTMLURL())
+				break
+			}
+		}
+	}
+
+	var ranked []*FlakyJob
+	for _, job := range flaky {
+		if job.Flakes > 0 {
+			sort.Strings(job.URLs)
+			ranked = append(ranked, job)
+		}
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Rate() != ranked[j].Rate() {
+			return ranked[i].Rate() > ranked[j].Rate()
+		}
+		if ranked[i].Flakes != ranked[j].Flakes {
+			return ranked[i].Flakes > ranked[j].Flakes
+		}
+		if ranked[i].Workflow != ranked[j].Workflow {
+			return ranked[i].Workflow < ranked[j].Workflow
+		}
+		return ranked[i].Job < ranked[j].Job
+	})
+
+	return ranked
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+)
+
+func newJob(name string, conclusion string, minute int, url string) *github.WorkflowJob {
+	completed := time.Date(2023, 10, 1, 12, minute, 0, 0, time.UTC)
+
+	return &github.WorkflowJob{
+		Name:        github.String(name),
+		Conclusion:  github.String(conclusion),
+		CompletedAt: &github.Timestamp{Time: completed},
+		HTMLURL:     github.String(url),
+	}
+}
+
+func TestDetectFlakyJobs(t *testing.T) {
+	runs := []*github.WorkflowRun{
+		{ID: github.Int64(1), WorkflowID: github.Int64(7), Name: github.String("CI for aaa"), HeadSHA: github.String("aaa")},
+		{ID: github.Int64(2), WorkflowID: github.Int64(7), Name: github.String("CI for bbb"), HeadSHA: github.String("bbb")},
+		{ID: github.Int64(3), WorkflowID: github.Int64(7), Name: github.String("CI for bbb"), HeadSHA: github.String("bbb")},
+	}
+	jobs := map[int64][]*github.WorkflowJob{
+		// re-run of a failed attempt on the same run
+		1: {
+			newJob("test", "success", 10, "test-1b"),
+			newJob("test", "failure", 5, "test-1a"),
+			newJob("lint", "success", 5, "lint-1"),
+		},
+		// a failed run followed by a new run for the same commit
+		2: {newJob("test", "failure", 20, "test-2"), newJob("lint", "failure", 20, "lint-2")},
+		3: {newJob("test", "success", 30, "test-3"), newJob("lint", "failure", 30, "lint-3")},
+	}
+
+	flaky := detectFlakyJobs(runs, jobs, map[int64]string{7: "CI"})
+	if len(flaky) != 1 {
+		t.Fatalf("expected 1 flaky job, but got %d", len(flaky))
+	}
+
+	job := flaky[0]
+	if job.Workflow != "CI" || job.Job != "test" || job.Flakes != 2 || job.Commits != 2 {
+		t.Errorf("expected CI / test to flake on 2 of 2 commits, but got %s / %s on %d of %d", job.Workflow, job.Job, job.Flakes, job.Commits)
+	}
+
+	if len(job.URLs) != 2 || job.URLs[0] != "test-1a" || job.URLs[1] != "test-2" {
+		t.Errorf("expected links to the failed attempts, but got %v", job.URLs)
+	}
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v55/github"
+)
+
+type graphqlRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+type graphqlError struct {
+	Message    string `json:"message"`
+	Extensions struct {
+		Code string `json:"code"`
+	} `json:"extensions"`
+}
+
+type GraphQLError struct {
+	Message string
+	Code    string
+}
+
+func (e *GraphQLError) Error() string {
+	return fmt.Sprintf("graphql request failed: %s", e.Message)
+}
+
+// isSchemaError reports whether a query failed because the server's schema lacks
+// a requested field, as happens on older GitHub Enterprise Server versions.
+func isSchemaError(err error) bool {
+	var graphqlErr *GraphQLError
+	if !errors.As(err, &graphqlErr) {
+		return false
+	}
+
+	return graphqlErr.Code == "undefinedField" || graphqlErr.Code == "undefinedType" || strings.Contains(graphqlErr.Message, "doesn't exist on type")
+}
+
+type graphqlResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []graphqlError  `json:"errors"`
+}
+
+func graphqlURL(client *github.Client) string {
+	baseURL := client.BaseURL.String()
+
+	// GitHub Enterprise Server serves REST under /api/v3/ and GraphQL under /api/graphql
+	if strings.HasSuffix(baseURL, "/api/v3/") {
+		return strings.TrimSuffix(baseURL, "v3/") + "graphql"
+	}
+
+	return baseURL + "graphql"
+}
+
+func graphqlQuery(client *github.Client, query string, variables map[string]interface{}, data interface{}) error {
+	body, err := json.Marshal(graphqlRequest{Query: query, Variables: variables})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, graphqlURL(client), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := client.Client().Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("graphql request failed with status %d", res.StatusCode)
+	}
+
+	var response graphqlResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return err
+	}
+
+	if len(response.Errors) > 0 {
+		return &GraphQLError{Message: response.Errors[0].Message, Code: response.Errors[0].Extensions.Code}
+	}
+
+	return json.Unmarshal(response.Data, data)
+}
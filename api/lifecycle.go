@@ -0,0 +1,306 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/go-github/v55/github"
+)
+
+type RepoCreate struct {
+	Name        string
+	Org         string
+	Description string
+	Visibility  string
+	Gitignore   string
+	License     string
+	Readme      bool
+	Template    string
+}
+
+type RepoEdit struct {
+	Description         *string
+	Homepage            *string
+	DefaultBranch       *string
+	Visibility          *string
+	AllowMergeCommit    *bool
+	AllowSquashMerge    *bool
+	AllowRebaseMerge    *bool
+	DeleteBranchOnMerge *bool
+	HasIssues           *bool
+	HasProjects         *bool
+	HasWiki             *bool
+	HasDiscussions      *bool
+	AddTopics           []string
+	RemoveTopics        []string
+}
+
+func PlanRepoCreate(create *RepoCreate) ([]*Mutation, error) {
+	switch create.Visibility {
+	case "public", "private", "internal":
+	default:
+		return nil, fmt.Errorf("invalid visibility '%s', use public, private or internal", create.Visibility)
+	}
+
+	if create.Template == "" {
+		return planRepoCreate(create), nil
+	}
+
+	if create.Gitignore != "" || create.License != "" || create.Readme {
+		return nil, fmt.Errorf("a repository created from a template cannot add a .gitignore, license or README")
+	}
+	if create.Visibility == "internal" {
+		return nil, fmt.Errorf("a repository created from a template can only be public or private")
+	}
+
+	owner, repo, err := parseRepoPath(create.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &github.TemplateRepoRequest{
+		Name:    github.String(create.Name),
+		Private: github.Bool(create.Visibility == "private"),
+	}
+	if create.Org != "" {
+		request.Owner = github.String(create.Org)
+	}
+	if create.Description != "" {
+		request.Description = github.String(create.Description)
+	}
+
+	mutation := &Mutation{
+		Method: "POST",
+		Path:   fmt.Sprintf("/repos/%s/%s/generate", owner, repo),
+		Body:   request,
+		apply: func(ctx context.Context, client *github.Client) error {
+			_, _, err := client.Repositories.CreateFromTemplate(ctx, owner, repo, request)
+			return err
+		},
+	}
+
+	return []*Mutation{mutation}, nil
+}
+
+func planRepoCreate(create *RepoCreate) []*Mutation {
+	repository := &github.Repository{
+		Name:       github.String(create.Name),
+		Visibility: github.String(create.Visibility),
+		Private:    github.Bool(create.Visibility != "public"),
+	}
+	if create.Description != "" {
+		repository.Description = github.String(create.Description)
+	}
+	if create.Gitignore != "" {
+		repository.GitignoreTemplate = github.String(create.Gitignore)
+	}
+	if create.License != "" {
+		repository.LicenseTemplate = github.String(create.License)
+	}
+	if create.Readme {
+		repository.AutoInit = github.Bool(true)
+	}
+
+	path := "/user/repos"
+	if create.Org != "" {
+		path = fmt.Sprintf("/orgs/%s/repos", create.Org)
+	}
+
+	org := create.Org
+	mutation := &Mutation{
+		Method: "POST",
+		Path:   path,
+		Body:   repository,
+		apply: func(ctx context.Context, client *github.Client) error {
+			_, _, err := client.Repositories.Create(ctx, org, repository)
+			return err
+		},
+	}
+
+	return []*Mutation{mutation}
+}
+
+func GetRepo(repoPath string) (*github.Repository, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	repository, _, err := client.Repositories.Get(context.Background(), owner, repo)
+	if err != nil {
+		msg := fmt.Errorf("could not retrieve repo '%s', make sure the repository exists and GITHUB_ACCESS_TOKEN is set and valid", repoPath)
+		return nil, msg
+	}
+
+	return repository, nil
+}
+
+func PlanRepoEdit(repoPath string, edit *RepoEdit) ([]*Mutation, error) {
+	repository, err := GetRepo(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return planRepoEdit(repository, edit), nil
+}
+
+// planRepoEdit only includes the settings that differ from the repository's
+// current ones.
+func planRepoEdit(repository *github.Repository, edit *RepoEdit) []*Mutation {
+	var mutations []*Mutation
+	owner := repository.GetOwner().GetLogin()
+	repo := repository.GetName()
+	path := fmt.Sprintf("/repos/%s/%s", owner, repo)
+
+	update := &github.Repository{}
+	changed := false
+	setString := func(field **string, value *string, current string) {
+		if value != nil && *value != current {
+			*field = value
+			changed = true
+		}
+	}
+	setBool := func(field **bool, value *bool, current bool) {
+		if value != nil && *value != current {
+			*field = value
+			changed = true
+		}
+	}
+
+	setString(&update.Description, edit.Description, repository.GetDescription())
+	setString(&update.Homepage, edit.Homepage, repository.GetHomepage())
+	setString(&update.DefaultBranch, edit.DefaultBranch, repository.GetDefaultBranch())
+	setString(&update.Visibility, edit.Visibility, repository.GetVisibility())
+	setBool(&update.AllowMergeCommit, edit.AllowMergeCommit, repository.GetAllowMergeCommit())
+	setBool(&update.AllowSquashMerge, edit.AllowSquashMerge, repository.GetAllowSquashMerge())
+	setBool(&update.AllowRebaseMerge, edit.AllowRebaseMerge, repository.GetAllowRebaseMerge())
+	setBool(&update.DeleteBranchOnMerge, edit.DeleteBranchOnMerge, repository.GetDeleteBranchOnMerge())
+	setBool(&update.HasIssues, edit.HasIssues, repository.GetHasIssues())
+	setBool(&update.HasProjects, edit.HasProjects, repository.GetHasProjects())
+	setBool(&update.HasWiki, edit.HasWiki, repository.GetHasWiki())
+	setBool(&update.HasDiscussions, edit.HasDiscussions, repository.GetHasDiscussions())
+
+	if changed {
+		mutations = append(mutations, &Mutation{
+			Method: "PATCH",
+			Path:   path,
+			Body:   update,
+			apply: func(ctx context.Context, client *github.Client) error {
+				_, _, err := client.Repositories.Edit(ctx, owner, repo, update)
+				return err
+			},
+		})
+	}
+
+	if topics, ok := editTopics(repository.Topics, edit.AddTopics, edit.RemoveTopics); ok {
+		mutations = append(mutations, &Mutation{
+			Method: "PUT",
+			Path:   path + "/topics",
+			Body:   map[string][]string{"names": topics},
+			apply: func(ctx context.Context, client *github.Client) error {
+				_, _, err := client.Repositories.ReplaceAllTopics(ctx, owner, repo, topics)
+				return err
+			},
+		})
+	}
+
+	return mutations
+}
+
+// editTopics returns the sorted topics after adding and removing the given
+// ones, and whether they differ from the current topics.
+func editTopics(current []string, add []string, remove []string) ([]string, bool) {
+	topics := make(map[string]bool)
+	for _, topic := range current {
+		topics[topic] = true
+	}
+
+	changed := false
+	for _, topic := range add {
+		if !topics[topic] {
+			topics[topic] = true
+			changed = true
+		}
+	}
+	for _, topic := range remove {
+		if topics[topic] {
+			delete(topics, topic)
+			changed = true
+		}
+	}
+
+	result := []string{}
+	for topic := range topics {
+		result = append(result, topic)
+	}
+	sort.Strings(result)
+
+	return result, changed
+}
+
+func PlanRepoArchive(repoPath string, archived bool) ([]*Mutation, error) {
+	repository, err := GetRepo(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if repository.GetArchived() == archived {
+		return nil, nil
+	}
+
+	return planRepoUpdate(repository, &github.Repository{Archived: github.Bool(archived)}), nil
+}
+
+func PlanRepoRename(repoPath string, name string) ([]*Mutation, error) {
+	repository, err := GetRepo(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if repository.GetName() == name {
+		return nil, nil
+	}
+
+	return planRepoUpdate(repository, &github.Repository{Name: github.String(name)}), nil
+}
+
+func planRepoUpdate(repository *github.Repository, update *github.Repository) []*Mutation {
+	owner := repository.GetOwner().GetLogin()
+	repo := repository.GetName()
+
+	mutation := &Mutation{
+		Method: "PATCH",
+		Path:   fmt.Sprintf("/repos/%s/%s", owner, repo),
+		Body:   update,
+		apply: func(ctx context.Context, client *github.Client) error {
+			_, _, err := client.Repositories.Edit(ctx, owner, repo, update)
+			return err
+		},
+	}
+
+	return []*Mutation{mutation}
+}
+
+func PlanRepoDelete(repoPath string) ([]*Mutation, error) {
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	mutation := &Mutation{
+		Method: "DELETE",
+		Path:   fmt.Sprintf("/repos/%s/%s", owner, repo),
+		apply: func(ctx context.Context, client *github.Client) error {
+			_, err := client.Repositories.Delete(ctx, owner, repo)
+			return err
+		},
+	}
+
+	return []*Mutation{mutation}, nil
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v55/github"
+)
+
+func TestPlanRepoCreate(t *testing.T) {
+	mutations := planRepoCreate(&RepoCreate{Name: "api", Org: "acme", Visibility: "internal", License: "mit"})
+
+	expected := `POST /orgs/acme/repos {"name":"api","private":true,"license_template":"mit","visibility":"internal"}`
+	if len(mutations) != 1 {
+		t.Fatalf("expected 1 mutation, but got %d", len(mutations))
+	}
+	if mutations[0].String() != expected {
+		t.Errorf("expected mutation '%s', but got '%s'", expected, mutations[0].String())
+	}
+}
+
+func TestPlanRepoCreateWithInvalidOptions(t *testing.T) {
+	creates := map[string]*RepoCreate{
+		"invalid visibility 'secret', use public, private or internal":                    {Name: "api", Visibility: "secret"},
+		"a repository created from a template cannot add a .gitignore, license or README": {Name: "api", Visibility: "private", Template: "acme/template", Readme: true},
+		"a repository created from a template can only be public or private":              {Name: "api", Visibility: "internal", Template: "acme/template"},
+	}
+
+	for expectedError, create := range creates {
+		_, err := PlanRepoCreate(create)
+		if err == nil {
+			t.Error(expectedErrorGotNil)
+		} else if err.Error() != expectedError {
+			t.Errorf(expectedDifferentError, expectedError, err.Error())
+		}
+	}
+}
+
+func TestPlanRepoCreateFromTemplate(t *testing.T) {
+	mutations, err := PlanRepoCreate(&RepoCreate{Name: "api", Visibility: "public", Template: "acme/template"})
+	if err != nil {
+		t.Fatalf(expectedNoError, err.Error())
+	}
+
+	expected := `POST /repos/acme/template/generate {"name":"api","private":false}`
+	if len(mutations) != 1 || mutations[0].String() != expected {
+		t.Errorf("expected mutation '%s', but got %v", expected, mutations)
+	}
+}
+
+func TestPlanRepoEdit(t *testing.T) {
+	repository := &github.Repository{
+		Name:             github.String("api"),
+		Owner:            &github.User{Login: github.String("acme")},
+		Description:      github.String("The acme API"),
+		AllowMergeCommit: github.Bool(true),
+		HasWiki:          github.Bool(true),
+		Topics:           []string{"go"},
+	}
+
+	description := "The acme API"
+	allowMergeCommit := false
+	wiki := true
+	mutations := planRepoEdit(repository, &RepoEdit{
+		Description:      &description,
+		AllowMergeCommit: &allowMergeCommit,
+		HasWiki:          &wiki,
+		AddTopics:        []string{"cli"},
+		RemoveTopics:     []string{"rust"},
+	})
+
+	expectedMutations := []string{
+		`PATCH /repos/acme/api {"allow_merge_commit":false}`,
+		`PUT /repos/acme/api/topics {"names":["cli","go"]}`,
+	}
+
+	if len(mutations) != len(expectedMutations) {
+		t.Fatalf("expected %d mutations, but got %d", len(expectedMutations), len(mutations))
+	}
+
+	for i, expected := range expectedMutations {
+		if mutations[i].String() != expected {
+			t.Errorf("expected mutation '%s', but got '%s'", expected, mutations[i].String())
+		}
+	}
+}
+
+func TestPlanRepoEditWithNoChanges(t *testing.T) {
+	repository := &github.Repository{Name: github.String("api"), Owner: &github.User{Login: github.String("acme")}, Topics: []string{"go"}}
+
+	mutations := planRepoEdit(repository, &RepoEdit{AddTopics: []string{"go"}, RemoveTopics: []string{"rust"}})
+
+	if len(mutations) != 0 {
+		t.Errorf("expected no mutations, but got %d", len(mutations))
+	}
+}
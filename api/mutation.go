@@ -0,0 +1,44 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/go-github/v55/github"
+)
+
+type Mutation struct {
+	Method string
+	Path   string
+	Body   interface{}
+	apply  func(ctx context.Context, client *github.Client) error
+}
+
+func (m *Mutation) String() string {
+	if m.Body == nil {
+		return fmt.Sprintf("%s %s", m.Method, m.Path)
+	}
+
+	body, err := json.Marshal(m.Body)
+	if err != nil {
+		return fmt.Sprintf("%s %s", m.Method, m.Path)
+	}
+
+	return fmt.Sprintf("%s %s %s", m.Method, m.Path, body)
+}
+
+func ApplyMutations(mutations []*Mutation) error {
+	client, err := getClientInstance()
+	if err != nil {
+		return err
+	}
+
+	for _, mutation := range mutations {
+		if err := mutation.apply(context.Background(), client); err != nil {
+			return fmt.Errorf("could not apply '%s %s', make sure you have write access and GITHUB_ACCESS_TOKEN is set and valid", mutation.Method, mutation.Path)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,582 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-github/v55/github"
+	"gopkg.in/yaml.v3"
+)
+
+// ProtectionState is the branch protection and the rulesets of a repository,
+// in the format read and written by gg repo protection.
+type ProtectionState struct {
+	Protection *BranchProtection `yaml:"protection"`
+	Rulesets   []*RulesetState   `yaml:"rulesets,omitempty"`
+}
+
+type BranchProtection struct {
+	RequiredStatusChecks           *StatusChecks   `yaml:"required_status_checks,omitempty"`
+	RequiredPullRequestReviews     *ReviewSettings `yaml:"required_pull_request_reviews,omitempty"`
+	EnforceAdmins                  bool            `yaml:"enforce_admins"`
+	Restrictions                   *Restrictions   `yaml:"restrictions,omitempty"`
+	RequiredLinearHistory          bool            `yaml:"required_linear_history"`
+	AllowForcePushes               bool            `yaml:"allow_force_pushes"`
+	AllowDeletions                 bool            `yaml:"allow_deletions"`
+	RequiredConversationResolution bool            `yaml:"required_conversation_resolution"`
+	LockBranch                     bool            `yaml:"lock_branch"`
+}
+
+// StatusChecks lists the required checks by context. AppIDs holds the GitHub
+// App that must report a check, for the checks tied to one.
+type StatusChecks struct {
+	Strict   bool             `yaml:"strict"`
+	Contexts []string         `yaml:"contexts"`
+	AppIDs   map[string]int64 `yaml:"app_ids,omitempty"`
+}
+
+type ReviewSettings struct {
+	DismissStaleReviews          bool          `yaml:"dismiss_stale_reviews"`
+	RequireCodeOwnerReviews      bool          `yaml:"require_code_owner_reviews"`
+	RequiredApprovingReviewCount int           `yaml:"required_approving_review_count"`
+	RequireLastPushApproval      bool          `yaml:"require_last_push_approval"`
+	DismissalRestrictions        *Restrictions `yaml:"dismissal_restrictions,omitempty"`
+	BypassPullRequestAllowances  *Restrictions `yaml:"bypass_pull_request_allowances,omitempty"`
+}
+
+type Restrictions struct {
+	Users []string `yaml:"users"`
+	Teams []string `yaml:"teams"`
+	Apps  []string `yaml:"apps"`
+}
+
+type RulesetState struct {
+	ID           int64          `yaml:"-"`
+	Name         string         `yaml:"name"`
+	Target       string         `yaml:"target"`
+	Enforcement  string         `yaml:"enforcement"`
+	Include      []string       `yaml:"include"`
+	Exclude      []string       `yaml:"exclude"`
+	BypassActors []*BypassActor `yaml:"bypass_actors,omitempty"`
+	Rules        []*RuleState   `yaml:"rules"`
+
+	// targets is set when the ref conditions match the branch
+	targets bool
+}
+
+type BypassActor struct {
+	ActorID    int64  `yaml:"actor_id"`
+	ActorType  string `yaml:"actor_type"`
+	BypassMode string `yaml:"bypass_mode"`
+}
+
+type RuleState struct {
+	Type       string                 `yaml:"type"`
+	Parameters map[string]interface{} `yaml:"parameters,omitempty"`
+}
+
+type ProtectionPlan struct {
+	Current   *ProtectionState
+	Desired   *ProtectionState
+	Mutations []*Mutation
+}
+
+func ParseProtectionState(content []byte) (*ProtectionState, error) {
+	var state ProtectionState
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&state); err != nil {
+		return nil, fmt.Errorf("could not parse the protection file: %v", err)
+	}
+
+	names := make(map[string]bool)
+	for _, ruleset := range state.Rulesets {
+		if ruleset.Name == "" {
+			return nil, fmt.Errorf("every ruleset in the protection file needs a name")
+		}
+		if names[ruleset.Name] {
+			return nil, fmt.Errorf("the ruleset '%s' appears more than once in the protection file", ruleset.Name)
+		}
+		names[ruleset.Name] = true
+	}
+
+	return &state, nil
+}
+
+func MarshalProtectionState(state *ProtectionState) (string, error) {
+	content, err := yaml.Marshal(state)
+	if err != nil {
+		return "", err
+	}
+
+	return string(content), nil
+}
+
+// GetProtectionState returns the branch protection and the rulesets whose ref
+// conditions match the branch.
+func GetProtectionState(repoPath string, branch string) (*ProtectionState, error) {
+	state, err := getProtectionState(repoPath, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProtectionState{Protection: state.Protection, Rulesets: branchRulesets(state.Rulesets, nil)}, nil
+}
+
+// getProtectionState returns the branch protection and every ruleset of the
+// repository, marking the ones that target the branch.
+func getProtectionState(repoPath string, branch string) (*ProtectionState, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := fmt.Errorf("could not retrieve the protection of branch '%s' in repo '%s', make sure you have admin access and GITHUB_ACCESS_TOKEN is set and valid", branch, repoPath)
+	state := &ProtectionState{}
+
+	protection, _, err := client.Repositories.GetBranchProtection(context.Background(), owner, repo, branch)
+	if err != nil && !errors.Is(err, github.ErrBranchNotProtected) {
+		return nil, msg
+	}
+	if protection != nil {
+		state.Protection = protectionFromGitHub(protection)
+	}
+
+	// GetAllRulesets only reads the first page, so page through them with raw requests
+	rulesets, err := paginate(math.MaxInt, false, func(page github.ListOptions) ([]*github.Ruleset, *github.Response, error) {
+		path := fmt.Sprintf("repos/%s/%s/rulesets?includes_parents=false&page=%d&per_page=%d", owner, repo, page.Page, page.PerPage)
+		req, err := client.NewRequest("GET", path, nil)
+		if err != nil {
+			return nil, nil, msg
+		}
+
+		var res []*github.Ruleset
+		resp, err := client.Do(context.Background(), req, &res)
+		if isNotFound(err) {
+			return nil, &github.Response{}, nil
+		}
+		if err != nil {
+			return nil, nil, msg
+		}
+
+		return res, resp, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var defaultBranch string
+	if len(rulesets) > 0 {
+		repository, _, err := client.Repositories.Get(context.Background(), owner, repo)
+		if err != nil {
+			return nil, msg
+		}
+		defaultBranch = repository.GetDefaultBranch()
+	}
+
+	// the list only has a summary of each ruleset, without its conditions and rules
+	for _, summary := range rulesets {
+		ruleset, _, err := client.Repositories.GetRuleset(context.Background(), owner, repo, summary.GetID(), false)
+		if err != nil {
+			return nil, msg
+		}
+
+		rulesetState, err := rulesetFromGitHub(ruleset)
+		if err != nil {
+			return nil, msg
+		}
+		rulesetState.targets = rulesetState.targetsBranch(branch, defaultBranch)
+		state.Rulesets = append(state.Rulesets, rulesetState)
+	}
+
+	return state, nil
+}
+
+// targetsBranch reports whether the ref conditions of a branch ruleset include
+// the branch and do not exclude it.
+func (r *RulesetState) targetsBranch(branch string, defaultBranch string) bool {
+	if r.Target != "branch" {
+		return false
+	}
+
+	ref := "refs/heads/" + branch
+	matches := func(patterns []string) bool {
+		for _, pattern := range patterns {
+			switch pattern {
+			case "~ALL":
+				return true
+			case "~DEFAULT_BRANCH":
+				if branch == defaultBranch {
+					return true
+				}
+			default:
+				if refPattern(pattern).MatchString(ref) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	return matches(r.Include) && !matches(r.Exclude)
+}
+
+// refPattern turns an fnmatch ref pattern into a regular expression, where *
+// does not match a slash and ** does.
+func refPattern(pattern string) *regexp.Regexp {
+	var expr strings.Builder
+	expr.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			expr.WriteString("[^/]*")
+		case pattern[i] == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	expr.WriteString("$")
+
+	return regexp.MustCompile(expr.String())
+}
+
+// branchRulesets returns the rulesets that target the branch, along with the
+// ones named in wanted.
+func branchRulesets(rulesets []*RulesetState, wanted map[string]*RulesetState) []*RulesetState {
+	var filtered []*RulesetState
+	for _, ruleset := range rulesets {
+		if _, ok := wanted[ruleset.Name]; ok || ruleset.targets {
+			filtered = append(filtered, ruleset)
+		}
+	}
+
+	return filtered
+}
+
+func protectionFromGitHub(protection *github.Protection) *BranchProtection {
+	state := &BranchProtection{
+		EnforceAdmins:                  protection.EnforceAdmins != nil && protection.EnforceAdmins.Enabled,
+		RequiredLinearHistory:          protection.RequireLinearHistory != nil && protection.RequireLinearHistory.Enabled,
+		AllowForcePushes:               protection.AllowForcePushes != nil && protection.AllowForcePushes.Enabled,
+		AllowDeletions:                 protection.AllowDeletions != nil && protection.AllowDeletions.Enabled,
+		RequiredConversationResolution: protection.RequiredConversationResolution != nil && protection.RequiredConversationResolution.Enabled,
+		LockBranch:                     protection.LockBranch != nil && protection.LockBranch.GetEnabled(),
+	}
+
+	if checks := protection.RequiredStatusChecks; checks != nil {
+		state.RequiredStatusChecks = &StatusChecks{Strict: checks.Strict, Contexts: []string{}}
+		for _, check := range checks.Checks {
+			state.RequiredStatusChecks.Contexts = append(state.RequiredStatusChecks.Contexts, check.Context)
+			if check.AppID != nil {
+				if state.RequiredStatusChecks.AppIDs == nil {
+					state.RequiredStatusChecks.AppIDs = make(map[string]int64)
+				}
+				state.RequiredStatusChecks.AppIDs[check.Context] = *check.AppID
+			}
+		}
+		if len(checks.Checks) == 0 {
+			state.RequiredStatusChecks.Contexts = append(state.RequiredStatusChecks.Contexts, checks.Contexts...)
+		}
+	}
+
+	if reviews := protection.RequiredPullRequestReviews; reviews != nil {
+		state.RequiredPullRequestReviews = &ReviewSettings{
+			DismissStaleReviews:          reviews.DismissStaleReviews,
+			RequireCodeOwnerReviews:      reviews.RequireCodeOwnerReviews,
+			RequiredApprovingReviewCount: reviews.RequiredApprovingReviewCount,
+			RequireLastPushApproval:      reviews.RequireLastPushApproval,
+		}
+		if dismissal := reviews.DismissalRestrictions; dismissal != nil {
+			state.RequiredPullRequestReviews.DismissalRestrictions = restrictionsFromGitHub(dismissal.Users, dismissal.Teams, dismissal.Apps)
+		}
+		if bypass := reviews.BypassPullRequestAllowances; bypass != nil {
+			state.RequiredPullRequestReviews.BypassPullRequestAllowances = restrictionsFromGitHub(bypass.Users, bypass.Teams, bypass.Apps)
+		}
+	}
+
+	if restrictions := protection.Restrictions; restrictions != nil {
+		state.Restrictions = restrictionsFromGitHub(restrictions.Users, restrictions.Teams, restrictions.Apps)
+	}
+
+	return state
+}
+
+func restrictionsFromGitHub(users []*github.User, teams []*github.Team, apps []*github.App) *Restrictions {
+	restrictions := &Restrictions{Users: []string{}, Teams: []string{}, Apps: []string{}}
+	for _, user := range users {
+		restrictions.Users = append(restrictions.Users, user.GetLogin())
+	}
+	for _, team := range teams {
+		restrictions.Teams = append(restrictions.Teams, team.GetSlug())
+	}
+	for _, app := range apps {
+		restrictions.Apps = append(restrictions.Apps, app.GetSlug())
+	}
+
+	return restrictions
+}
+
+func (p *BranchProtection) request() *github.ProtectionRequest {
+	request := &github.ProtectionRequest{
+		EnforceAdmins:                  p.EnforceAdmins,
+		RequireLinearHistory:           github.Bool(p.RequiredLinearHistory),
+		AllowForcePushes:               github.Bool(p.AllowForcePushes),
+		AllowDeletions:                 github.Bool(p.AllowDeletions),
+		RequiredConversationResolution: github.Bool(p.RequiredConversationResolution),
+		LockBranch:                     github.Bool(p.LockBranch),
+	}
+
+	if p.RequiredStatusChecks != nil {
+		checks := []*github.RequiredStatusCheck{}
+		for _, check := range p.RequiredStatusChecks.Contexts {
+			requiredCheck := &github.RequiredStatusCheck{Context: check}
+			if appID, ok := p.RequiredStatusChecks.AppIDs[check]; ok {
+				requiredCheck.AppID = github.Int64(appID)
+			}
+			checks = append(checks, requiredCheck)
+		}
+		request.RequiredStatusChecks = &github.RequiredStatusChecks{Strict: p.RequiredStatusChecks.Strict, Checks: checks}
+	}
+
+	if reviews := p.RequiredPullRequestReviews; reviews != nil {
+		request.RequiredPullRequestReviews = &github.PullRequestReviewsEnforcementRequest{
+			DismissStaleReviews:          reviews.DismissStaleReviews,
+			RequireCodeOwnerReviews:      reviews.RequireCodeOwnerReviews,
+			RequiredApprovingReviewCount: reviews.RequiredApprovingReviewCount,
+			RequireLastPushApproval:      github.Bool(reviews.RequireLastPushApproval),
+		}
+		// leaving either out of the request clears it
+		if dismissal := reviews.DismissalRestrictions; dismissal != nil {
+			users := append([]string{}, dismissal.Users...)
+			teams := append([]string{}, dismissal.Teams...)
+			apps := append([]string{}, dismissal.Apps...)
+			request.RequiredPullRequestReviews.DismissalRestrictionsRequest = &github.DismissalRestrictionsRequest{Users: &users, Teams: &teams, Apps: &apps}
+		}
+		if bypass := reviews.BypassPullRequestAllowances; bypass != nil {
+			request.RequiredPullRequestReviews.BypassPullRequestAllowancesRequest = &github.BypassPullRequestAllowancesRequest{
+				Users: append([]string{}, bypass.Users...),
+				Teams: append([]string{}, bypass.Teams...),
+				Apps:  append([]string{}, bypass.Apps...),
+			}
+		}
+	}
+
+	if restrictions := p.Restrictions; restrictions != nil {
+		request.Restrictions = &github.BranchRestrictionsRequest{Users: []string{}, Teams: []string{}, Apps: []string{}}
+		request.Restrictions.Users = append(request.Restrictions.Users, restrictions.Users...)
+		request.Restrictions.Teams = append(request.Restrictions.Teams, restrictions.Teams...)
+		request.Restrictions.Apps = append(request.Restrictions.Apps, restrictions.Apps...)
+	}
+
+	return request
+}
+
+func rulesetFromGitHub(ruleset *github.Ruleset) (*RulesetState, error) {
+	state := &RulesetState{
+		ID:          ruleset.GetID(),
+		Name:        ruleset.Name,
+		Target:      ruleset.GetTarget(),
+		Enforcement: ruleset.Enforcement,
+		Include:     []string{},
+		Exclude:     []string{},
+		Rules:       []*RuleState{},
+	}
+
+	if conditions := ruleset.Conditions; conditions != nil && conditions.RefName != nil {
+		state.Include = append(state.Include, conditions.RefName.Include...)
+		state.Exclude = append(state.Exclude, conditions.RefName.Exclude...)
+	}
+
+	for _, actor := range ruleset.BypassActors {
+		state.BypassActors = append(state.BypassActors, &BypassActor{
+			ActorID:    actor.GetActorID(),
+			ActorType:  actor.GetActorType(),
+			BypassMode: actor.GetBypassMode(),
+		})
+	}
+
+	for _, rule := range ruleset.Rules {
+		ruleState := &RuleState{Type: rule.Type}
+		if rule.Parameters != nil {
+			if err := json.Unmarshal(*rule.Parameters, &ruleState.Parameters); err != nil {
+				return nil, err
+			}
+		}
+		state.Rules = append(state.Rules, ruleState)
+	}
+
+	return state, nil
+}
+
+func (r *RulesetState) ruleset() (*github.Ruleset, error) {
+	ruleset := &github.Ruleset{
+		Name:        r.Name,
+		Target:      github.String(r.Target),
+		Enforcement: r.Enforcement,
+		Conditions: &github.RulesetConditions{RefName: &github.RulesetRefConditionParameters{
+			Include: append([]string{}, r.Include...),
+			Exclude: append([]string{}, r.Exclude...),
+		}},
+		Rules: []*github.RepositoryRule{},
+	}
+
+	for _, actor := range r.BypassActors {
+		ruleset.BypassActors = append(ruleset.BypassActors, &github.BypassActor{
+			ActorID:    github.Int64(actor.ActorID),
+			ActorType:  github.String(actor.ActorType),
+			BypassMode: github.String(actor.BypassMode),
+		})
+	}
+
+	for _, rule := range r.Rules {
+		repositoryRule := &github.RepositoryRule{Type: rule.Type}
+		if len(rule.Parameters) > 0 {
+			parameters, err := json.Marshal(rule.Parameters)
+			if err != nil {
+				return nil, fmt.Errorf("invalid parameters for rule '%s' of ruleset '%s'", rule.Type, r.Name)
+			}
+			raw := json.RawMessage(parameters)
+			repositoryRule.Parameters = &raw
+		}
+		ruleset.Rules = append(ruleset.Rules, repositoryRule)
+	}
+
+	return ruleset, nil
+}
+
+func PlanProtectionApply(repoPath string, branch string, desired *ProtectionState) (*ProtectionPlan, error) {
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := getProtectionState(repoPath, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	return planProtectionApply(owner, repo, branch, current, desired)
+}
+
+// planProtectionApply brings the branch protection and the rulesets named in
+// desired to their desired state. current holds every ruleset of the
+// repository, so a ruleset is updated by name even when it does not target the
+// branch yet. Rulesets that target the branch but are not in desired are kept
+// as they are, so the returned plan's Desired state includes them.
+func planProtectionApply(owner string, repo string, branch string, current *ProtectionState, desired *ProtectionState) (*ProtectionPlan, error) {
+	plan := &ProtectionPlan{Current: &ProtectionState{Protection: current.Protection}, Desired: &ProtectionState{Protection: desired.Protection}}
+	protectionPath := fmt.Sprintf("/repos/%s/%s/branches/%s/protection", owner, repo, branch)
+	rulesetsPath := fmt.Sprintf("/repos/%s/%s/rulesets", owner, repo)
+
+	switch {
+	case desired.Protection == nil && current.Protection != nil:
+		plan.Mutations = append(plan.Mutations, &Mutation{
+			Method: "DELETE",
+			Path:   protectionPath,
+			apply: func(ctx context.Context, client *github.Client) error {
+				_, err := client.Repositories.RemoveBranchProtection(ctx, owner, repo, branch)
+				return err
+			},
+		})
+	case desired.Protection != nil && !sameYAML(desired.Protection, current.Protection):
+		request := desired.Protection.request()
+		plan.Mutations = append(plan.Mutations, &Mutation{
+			Method: "PUT",
+			Path:   protectionPath,
+			Body:   request,
+			apply: func(ctx context.Context, client *github.Client) error {
+				_, _, err := client.Repositories.UpdateBranchProtection(ctx, owner, repo, branch, request)
+				return err
+			},
+		})
+	}
+
+	wanted := make(map[string]*RulesetState)
+	for _, ruleset := range desired.Rulesets {
+		wanted[ruleset.Name] = ruleset
+	}
+
+	plan.Current.Rulesets = branchRulesets(current.Rulesets, wanted)
+
+	existing := make(map[string]*RulesetState)
+	for _, ruleset := range current.Rulesets {
+		existing[ruleset.Name] = ruleset
+	}
+	for _, ruleset := range plan.Current.Rulesets {
+		if desiredRuleset, ok := wanted[ruleset.Name]; ok {
+			plan.Desired.Rulesets = append(plan.Desired.Rulesets, desiredRuleset)
+		} else {
+			plan.Desired.Rulesets = append(plan.Desired.Rulesets, ruleset)
+		}
+	}
+
+	for _, desiredRuleset := range desired.Rulesets {
+		currentRuleset, ok := existing[desiredRuleset.Name]
+		if ok && sameYAML(desiredRuleset, currentRuleset) {
+			continue
+		}
+
+		ruleset, err := desiredRuleset.ruleset()
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			plan.Desired.Rulesets = append(plan.Desired.Rulesets, desiredRuleset)
+			plan.Mutations = append(plan.Mutations, &Mutation{
+				Method: "POST",
+				Path:   rulesetsPath,
+				Body:   ruleset,
+				apply: func(ctx context.Context, client *github.Client) error {
+					_, _, err := client.Repositories.CreateRuleset(ctx, owner, repo, ruleset)
+					return err
+				},
+			})
+			continue
+		}
+
+		id := currentRuleset.ID
+		plan.Mutations = append(plan.Mutations, &Mutation{
+			Method: "PUT",
+			Path:   fmt.Sprintf("%s/%d", rulesetsPath, id),
+			Body:   ruleset,
+			apply: func(ctx context.Context, client *github.Client) error {
+				_, _, err := client.Repositories.UpdateRuleset(ctx, owner, repo, id, ruleset)
+				return err
+			},
+		})
+	}
+
+	return plan, nil
+}
+
+// sameYAML compares two values by their YAML form, which ignores the
+// difference between the numbers decoded from JSON and from YAML.
+func sameYAML(a interface{}, b interface{}) bool {
+	first, err := yaml.Marshal(a)
+	if err != nil {
+		return false
+	}
+	second, err := yaml.Marshal(b)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(first, second)
+}
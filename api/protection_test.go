@@ -0,0 +1,191 @@
+package api
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v55/github"
+)
+
+const protectionYAML = `protection:
+  required_status_checks:
+    strict: true
+    contexts: [build]
+  required_pull_request_reviews:
+    required_approving_review_count: 2
+  enforce_admins: true
+rulesets:
+  - name: main
+    target: branch
+    enforcement: active
+    include: ["~DEFAULT_BRANCH"]
+    rules:
+      - type: deletion
+      - type: pull_request
+        parameters:
+          required_approving_review_count: 1
+`
+
+func TestParseProtectionStateWithInvalidFiles(t *testing.T) {
+	files := map[string]string{
+		"protection:\n  enforce_admin: true\n":        "could not parse the protection file: yaml: unmarshal errors:\n  line 2: field enforce_admin not found in type api.BranchProtection",
+		"rulesets:\n  - name: main\n  - name: main\n": "the ruleset 'main' appears more than once in the protection file",
+		"rulesets:\n  - target: branch\n":             "every ruleset in the protection file needs a name",
+	}
+
+	for content, expectedError := range files {
+		_, err := ParseProtectionState([]byte(content))
+		if err == nil {
+			t.Error(expectedErrorGotNil)
+		} else if err.Error() != expectedError {
+			t.Errorf(expectedDifferentError, expectedError, err.Error())
+		}
+	}
+}
+
+func TestPlanProtectionApply(t *testing.T) {
+	desired, err := ParseProtectionState([]byte(protectionYAML))
+	if err != nil {
+		t.Fatalf(expectedNoError, err.Error())
+	}
+
+	current := &ProtectionState{Rulesets: []*RulesetState{
+		{ID: 7, Name: "main", Target: "branch", Enforcement: "evaluate", Include: []string{"~DEFAULT_BRANCH"}, targets: true},
+		{ID: 8, Name: "tags", Target: "tag", Enforcement: "active"},
+		{ID: 9, Name: "history", Target: "branch", Enforcement: "active", Include: []string{"~ALL"}, targets: true},
+	}}
+
+	plan, err := planProtectionApply("acme", "api", "main", current, desired)
+	if err != nil {
+		t.Fatalf(expectedNoError, err.Error())
+	}
+
+	expectedMutations := []string{
+		`PUT /repos/acme/api/branches/main/protection {"required_status_checks":{"strict":true,"checks":[{"context":"build"}]},"required_pull_request_reviews":{"dismiss_stale_reviews":false,"require_code_owner_reviews":false,"required_approving_review_count":2,"require_last_push_approval":false},"enforce_admins":true,"restrictions":null,"required_linear_history":false,"allow_force_pushes":false,"allow_deletions":false,"required_conversation_resolution":false,"lock_branch":false}`,
+		`PUT /repos/acme/api/rulesets/7 {"name":"main","target":"branch","source":"","enforcement":"active","conditions":{"ref_name":{"include":["~DEFAULT_BRANCH"],"exclude":[]}},"rules":[{"type":"deletion"},{"type":"pull_request","parameters":{"required_approving_review_count":1}}]}`,
+	}
+
+	if len(plan.Mutations) != len(expectedMutations) {
+		t.Fatalf("expected %d mutations, but got %d", len(expectedMutations), len(plan.Mutations))
+	}
+	for i, expected := range expectedMutations {
+		if plan.Mutations[i].String() != expected {
+			t.Errorf("expected mutation '%s', but got '%s'", expected, plan.Mutations[i].String())
+		}
+	}
+
+	if len(plan.Desired.Rulesets) != 2 || plan.Desired.Rulesets[1].Name != "history" {
+		t.Errorf("expected the rulesets of the branch missing from the file to be kept, got %+v", plan.Desired.Rulesets)
+	}
+	if len(plan.Current.Rulesets) != 2 {
+		t.Errorf("expected only the rulesets of the branch in the current state, got %+v", plan.Current.Rulesets)
+	}
+}
+
+func TestRulesetTargetsBranch(t *testing.T) {
+	rulesets := map[*RulesetState]bool{
+		{Target: "branch", Include: []string{"~DEFAULT_BRANCH"}}:                                           true,
+		{Target: "branch", Include: []string{"~ALL"}, Exclude: []string{"refs/heads/main"}}:                false,
+		{Target: "branch", Include: []string{"refs/heads/release/*"}}:                                      false,
+		{Target: "branch", Include: []string{"refs/heads/ma*"}}:                                            true,
+		{Target: "branch", Include: []string{"refs/heads/**"}, Exclude: []string{"refs/heads/release/**"}}: true,
+		{Target: "tag", Include: []string{"~ALL"}}:                                                         false,
+	}
+
+	for ruleset, expected := range rulesets {
+		if ruleset.targetsBranch("main", "main") != expected {
+			t.Errorf("expected ruleset with include %v and exclude %v to target main: %t", ruleset.Include, ruleset.Exclude, expected)
+		}
+	}
+}
+
+func TestPlanProtectionApplyWithNoChanges(t *testing.T) {
+	parameters := json.RawMessage(`{"required_approving_review_count":1}`)
+	ruleset, err := rulesetFromGitHub(&github.Ruleset{
+		ID:          github.Int64(7),
+		Name:        "main",
+		Target:      github.String("branch"),
+		Enforcement: "active",
+		Conditions:  &github.RulesetConditions{RefName: &github.RulesetRefConditionParameters{Include: []string{"~DEFAULT_BRANCH"}}},
+		Rules:       []*github.RepositoryRule{{Type: "deletion"}, {Type: "pull_request", Parameters: &parameters}},
+	})
+	if err != nil {
+		t.Fatalf(expectedNoError, err.Error())
+	}
+
+	current := &ProtectionState{
+		Protection: protectionFromGitHub(&github.Protection{
+			RequiredStatusChecks:       &github.RequiredStatusChecks{Strict: true, Checks: []*github.RequiredStatusCheck{{Context: "build"}}},
+			RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{RequiredApprovingReviewCount: 2},
+			EnforceAdmins:              &github.AdminEnforcement{Enabled: true},
+		}),
+		Rulesets: []*RulesetState{ruleset},
+	}
+
+	desired, err := ParseProtectionState([]byte(protectionYAML))
+	if err != nil {
+		t.Fatalf(expectedNoError, err.Error())
+	}
+
+	plan, err := planProtectionApply("acme", "api", "main", current, desired)
+	if err != nil {
+		t.Fatalf(expectedNoError, err.Error())
+	}
+
+	if len(plan.Mutations) != 0 {
+		t.Errorf("expected no mutations, but got %v", plan.Mutations)
+	}
+}
+
+func TestPlanProtectionApplyRemovesProtection(t *testing.T) {
+	current := &ProtectionState{Protection: &BranchProtection{EnforceAdmins: true}}
+
+	plan, err := planProtectionApply("acme", "api", "main", current, &ProtectionState{})
+	if err != nil {
+		t.Fatalf(expectedNoError, err.Error())
+	}
+
+	expected := "DELETE /repos/acme/api/branches/main/protection"
+	if len(plan.Mutations) != 1 || plan.Mutations[0].String() != expected {
+		t.Errorf("expected mutation '%s', but got %v", expected, plan.Mutations)
+	}
+}
+
+func TestProtectionKeepsReviewAllowancesAndCheckApps(t *testing.T) {
+	protection := &github.Protection{
+		RequiredStatusChecks: &github.RequiredStatusChecks{
+			Checks: []*github.RequiredStatusCheck{{Context: "build", AppID: github.Int64(15368)}, {Context: "lint"}},
+		},
+		RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{
+			RequiredApprovingReviewCount: 1,
+			DismissalRestrictions:        &github.DismissalRestrictions{Teams: []*github.Team{{Slug: github.String("leads")}}},
+			BypassPullRequestAllowances:  &github.BypassPullRequestAllowances{Users: []*github.User{{Login: github.String("release-bot")}}},
+		},
+	}
+
+	content, err := MarshalProtectionState(&ProtectionState{Protection: protectionFromGitHub(protection)})
+	if err != nil {
+		t.Fatalf(expectedNoError, err.Error())
+	}
+
+	state, err := ParseProtectionState([]byte(content))
+	if err != nil {
+		t.Fatalf(expectedNoError, err.Error())
+	}
+
+	request, err := json.Marshal(state.Protection.request())
+	if err != nil {
+		t.Fatalf(expectedNoError, err.Error())
+	}
+
+	for _, expected := range []string{
+		`"checks":[{"context":"build","app_id":15368},{"context":"lint"}]`,
+		`"dismissal_restrictions":{"users":[],"teams":["leads"],"apps":[]}`,
+		`"bypass_pull_request_allowances":{"users":["release-bot"],"teams":[],"apps":[]}`,
+	} {
+		if !strings.Contains(string(request), expected) {
+			t.Errorf("expected the request to contain '%s', but got '%s'", expected, request)
+		}
+	}
+}
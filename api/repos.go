@@ -0,0 +1,448 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+)
+
+type RepoFilter struct {
+	Type       string
+	Visibility string
+	Language   string
+	Topic      string
+	NoArchived bool
+	Source     bool
+	Fork       bool
+	Sort       string
+	Direction  string
+}
+
+// listOptions returns the filters GitHub applies server-side. Affiliation and
+// visibility are only supported when listing the authenticated user's repos.
+func (f RepoFilter) listOptions(authenticated bool) (*github.RepositoryListOptions, error) {
+	options := &github.RepositoryListOptions{}
+
+	switch f.Visibility {
+	case "", "all", "public", "private":
+	default:
+		return nil, fmt.Errorf("invalid visibility '%s', use all, public or private", f.Visibility)
+	}
+
+	if authenticated {
+		options.Visibility = f.Visibility
+		switch f.Type {
+		case "":
+			if f.Visibility != "" {
+				options.Affiliation = "owner"
+			}
+		case "owner", "collaborator":
+			options.Affiliation = f.Type
+		case "member":
+			options.Affiliation = "organization_member"
+		default:
+			return nil, fmt.Errorf("invalid type '%s', use owner, member or collaborator", f.Type)
+		}
+		return options, nil
+	}
+
+	switch f.Type {
+	case "", "owner", "member":
+		options.Type = f.Type
+	case "collaborator":
+		return nil, fmt.Errorf("collaborator repositories can only be listed for the authenticated user")
+	default:
+		return nil, fmt.Errorf("invalid type '%s', use owner, member or collaborator", f.Type)
+	}
+
+	return options, nil
+}
+
+// clientSide reports whether some repositories may be dropped after they are
+// fetched, in which case full pages are requested.
+func (f RepoFilter) clientSide() bool {
+	return f.Visibility != "" || f.Language != "" || f.Topic != "" || f.NoArchived || f.Source || f.Fork
+}
+
+// serverSort returns the sort and direction to ask GitHub for, and false when
+// the sort field is only supported client-side.
+func (f RepoFilter) serverSort() (string, string, bool) {
+	direction := f.Direction
+	if direction == "" {
+		direction = "desc"
+		if f.Sort == "name" {
+			direction = "asc"
+		}
+	}
+
+	switch f.Sort {
+	case "pushed", "updated":
+		return f.Sort, direction, true
+	case "name":
+		return "full_name", direction, true
+	}
+
+	return "", "", false
+}
+
+func (f RepoFilter) matches(repo *github.Repository) bool {
+	if f.Visibility != "" && f.Visibility != "all" && repo.GetVisibility() != f.Visibility {
+		return false
+	}
+	if f.Language != "" && !strings.EqualFold(repo.GetLanguage(), f.Language) {
+		return false
+	}
+	if f.Topic != "" && !hasTopic(repo, f.Topic) {
+		return false
+	}
+	if f.NoArchived && repo.GetArchived() {
+		return false
+	}
+	if f.Source && repo.GetFork() {
+		return false
+	}
+	if f.Fork && !repo.GetFork() {
+		return false
+	}
+
+	return true
+}
+
+func (f RepoFilter) apply(repos []*github.Repository) []*github.Repository {
+	var filtered []*github.Repository
+	for _, repo := range repos {
+		if f.matches(repo) {
+			filtered = append(filtered, repo)
+		}
+	}
+
+	return filtered
+}
+
+func hasTopic(repo *github.Repository, topic string) bool {
+	for _, t := range repo.Topics {
+		if strings.EqualFold(t, topic) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// listRepos pages through a repository listing until size repositories pass
+// the filter or there are no more pages, and sorts them. When the listing is
+// not sorted by GitHub, every page is fetched before sorting and truncating.
+func listRepos(size int, filter RepoFilter, serverSorted bool, list func(page github.ListOptions) ([]*github.Repository, *github.Response, error)) ([]*github.Repository, error) {
+	less, _, err := repoLess(filter.Sort, filter.Direction)
+	if err != nil {
+		return nil, err
+	}
+	if size <= 0 {
+		return []*github.Repository{}, nil
+	}
+
+	limit := size
+	if less != nil && !serverSorted {
+		limit = math.MaxInt
+	}
+
+	repos, err := paginate(limit, filter.clientSide(), func(page github.ListOptions) ([]*github.Repository, *github.Response, error) {
+		res, resp, err := list(page)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return filter.apply(res), resp, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := SortRepos(repos, filter.Sort, filter.Direction); err != nil {
+		return nil, err
+	}
+	if len(repos) > size {
+		repos = repos[:size]
+	}
+
+	return repos, nil
+}
+
+func GetOrgRepos(org string, size int, filter RepoFilter) ([]*github.Repository, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.Type != "" {
+		return nil, fmt.Errorf("filtering by type is only supported for user repositories")
+	}
+
+	options := github.RepositoryListByOrgOptions{}
+	if filter.Visibility == "public" || filter.Visibility == "private" {
+		options.Type = filter.Visibility
+	}
+	var serverSorted bool
+	options.Sort, options.Direction, serverSorted = filter.serverSort()
+
+	return listRepos(size, filter, serverSorted, func(page github.ListOptions) ([]*github.Repository, *github.Response, error) {
+		options.ListOptions = page
+		res, resp, err := client.Repositories.ListByOrg(context.Background(), org, &options)
+		if err != nil {
+			msg := fmt.Errorf("could not retrieve repositories for organization '%s', make sure the organization exists and GITHUB_ACCESS_TOKEN is set and valid", org)
+			return nil, nil, msg
+		}
+		return res, resp, nil
+	})
+}
+
+// GetTeamRepos lists the repositories a team has access to, with the team
+// given as org/team-slug.
+func GetTeamRepos(team string, size int, filter RepoFilter) ([]*github.Repository, error) {
+	org, slug, found := strings.Cut(team, "/")
+	if !found || org == "" || slug == "" || strings.Contains(slug, "/") {
+		return nil, fmt.Errorf("invalid team '%s', use the <org/team> format", team)
+	}
+
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.Type != "" {
+		return nil, fmt.Errorf("filtering by type is only supported for user repositories")
+	}
+
+	return listRepos(size, filter, false, func(page github.ListOptions) ([]*github.Repository, *github.Response, error) {
+		res, resp, err := client.Teams.ListTeamReposBySlug(context.Background(), org, slug, &page)
+		if err != nil {
+			msg := fmt.Errorf("could not retrieve repositories for team '%s', make sure the team exists and GITHUB_ACCESS_TOKEN is set and valid", team)
+			return nil, nil, msg
+		}
+		return res, resp, nil
+	})
+}
+
+// IsOrganization reports whether an account name belongs to an organization
+// rather than a user.
+func IsOrganization(name string) (bool, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return false, err
+	}
+
+	account, _, err := client.Users.Get(context.Background(), name)
+	if err != nil {
+		msg := fmt.Errorf("could not retrieve account '%s', make sure the name is valid and GITHUB_ACCESS_TOKEN is set and valid", name)
+		return false, msg
+	}
+
+	return account.GetType() == "Organization", nil
+}
+
+// SortRepos orders repositories in place by stars, pushed, updated or name.
+// An empty direction sorts names ascending and everything else descending.
+func SortRepos(repos []*github.Repository, field string, direction string) error {
+	less, ascending, err := repoLess(field, direction)
+	if err != nil || less == nil {
+		return err
+	}
+
+	sort.SliceStable(repos, func(i, j int) bool {
+		if ascending {
+			return less(repos[i], repos[j])
+		}
+		return less(repos[j], repos[i])
+	})
+
+	return nil
+}
+
+func repoLess(field string, direction string) (func(a *github.Repository, b *github.Repository) bool, bool, error) {
+	if direction == "" {
+		direction = "desc"
+		if field == "name" {
+			direction = "asc"
+		}
+	}
+	if direction != "asc" && direction != "desc" {
+		return nil, false, fmt.Errorf("invalid direction '%s', use asc or desc", direction)
+	}
+
+	var less func(a *github.Repository, b *github.Repository) bool
+	switch field {
+	case "":
+	case "stars":
+		less = func(a *github.Repository, b *github.Repository) bool {
+			return a.GetStargazersCount() < b.GetStargazersCount()
+		}
+	case "pushed":
+		less = func(a *github.Repository, b *github.Repository) bool {
+			return a.GetPushedAt().Before(b.GetPushedAt().Time)
+		}
+	case "updated":
+		less = func(a *github.Repository, b *github.Repository) bool {
+			return a.GetUpdatedAt().Before(b.GetUpdatedAt().Time)
+		}
+	case "name":
+		less = func(a *github.Repository, b *github.Repository) bool {
+			return strings.ToLower(a.GetFullName()) < strings.ToLower(b.GetFullName())
+		}
+	default:
+		return nil, false, fmt.Errorf("invalid sort field '%s', use stars, pushed, updated or name", field)
+	}
+
+	return less, direction == "asc", nil
+}
+
+type LanguageShare struct {
+	Name    string
+	Percent float64
+}
+
+type RepoDetails struct {
+	Repo          *github.Repository
+	Languages     []LanguageShare
+	Contributors  int
+	LatestRelease *github.RepositoryRelease
+	Readme        string
+}
+
+func GetRepoDetails(repoPath string, ref string) (*RepoDetails, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := fmt.Errorf("could not retrieve repo '%s', make sure the repository exists and GITHUB_ACCESS_TOKEN is set and valid", repoPath)
+
+	repository, _, err := client.Repositories.Get(context.Background(), owner, repo)
+	if err != nil {
+		return nil, msg
+	}
+
+	languages, _, err := client.Repositories.ListLanguages(context.Background(), owner, repo)
+	if err != nil {
+		return nil, msg
+	}
+
+	// With one contributor per page, the last page is the number of contributors.
+	contributors, resp, err := client.Repositories.ListContributors(context.Background(), owner, repo, &github.ListContributorsOptions{Anon: "true", ListOptions: github.ListOptions{PerPage: 1}})
+	if err != nil {
+		return nil, msg
+	}
+	count := len(contributors)
+	if resp.LastPage != 0 {
+		count = resp.LastPage
+	}
+
+	release, _, err := client.Repositories.GetLatestRelease(context.Background(), owner, repo)
+	if err != nil && !isNotFound(err) {
+		return nil, msg
+	}
+
+	readme, _, err := client.Repositories.GetReadme(context.Background(), owner, repo, &github.RepositoryContentGetOptions{Ref: ref})
+	if err != nil && !isNotFound(err) {
+		return nil, msg
+	}
+	if err != nil && ref != "" {
+		return nil, fmt.Errorf("could not find a README on '%s' in repo '%s', make sure the branch exists", ref, repoPath)
+	}
+
+	content := ""
+	if readme != nil {
+		content, err = readme.GetContent()
+		if err != nil {
+			return nil, msg
+		}
+	}
+
+	return &RepoDetails{
+		Repo:          repository,
+		Languages:     languageShares(languages),
+		Contributors:  count,
+		LatestRelease: release,
+		Readme:        content,
+	}, nil
+}
+
+// languageShares turns the bytes of code per language into percentages,
+// largest first.
+func languageShares(languages map[string]int) []LanguageShare {
+	total := 0
+	for _, bytes := range languages {
+		total += bytes
+	}
+
+	var shares []LanguageShare
+	for name, bytes := range languages {
+		shares = append(shares, LanguageShare{Name: name, Percent: float64(bytes) * 100 / float64(total)})
+	}
+
+	sort.Slice(shares, func(i, j int) bool {
+		if shares[i].Percent != shares[j].Percent {
+			return shares[i].Percent > shares[j].Percent
+		}
+		return shares[i].Name < shares[j].Name
+	})
+
+	return shares
+}
+
+func isNotFound(err error) bool {
+	var errorResponse *github.ErrorResponse
+	return errors.As(err, &errorResponse) && errorResponse.Response != nil && errorResponse.Response.StatusCode == http.StatusNotFound
+}
+
+const forkPollInterval = 2 * time.Second
+
+// ForkRepo forks a repository, into org when it is set, and waits up to
+// timeout for GitHub to finish creating the fork.
+func ForkRepo(repoPath string, org string, timeout time.Duration) (*github.Repository, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// the fork is created in the background with a 202, which go-github reports as an error
+	fork, _, err := client.Repositories.CreateFork(context.Background(), owner, repo, &github.RepositoryCreateForkOptions{Organization: org})
+	var accepted *github.AcceptedError
+	if err != nil && !errors.As(err, &accepted) {
+		msg := fmt.Errorf("could not fork repo '%s', make sure the repository exists and GITHUB_ACCESS_TOKEN is set and valid", repoPath)
+		return nil, msg
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		available, _, err := client.Repositories.Get(context.Background(), fork.GetOwner().GetLogin(), fork.GetName())
+		if err == nil {
+			return available, nil
+		}
+		if !isNotFound(err) {
+			msg := fmt.Errorf("could not retrieve the fork '%s' of repo '%s', make sure GITHUB_ACCESS_TOKEN is set and valid", fork.GetFullName(), repoPath)
+			return nil, msg
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("the fork '%s' of repo '%s' did not become available in time, try again later", fork.GetFullName(), repoPath)
+		}
+
+		time.Sleep(forkPollInterval)
+	}
+}
@@ -0,0 +1,220 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+)
+
+func TestSortRepos(t *testing.T) {
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	newRepos := func() []*github.Repository {
+		return []*github.Repository{
+			{FullName: github.String("acme/beta"), StargazersCount: github.Int(5), PushedAt: &github.Timestamp{Time: now.Add(-time.Hour)}},
+			{FullName: github.String("acme/Alpha"), StargazersCount: github.Int(50), PushedAt: &github.Timestamp{Time: now.Add(-48 * time.Hour)}},
+			{FullName: github.String("acme/gamma"), StargazersCount: github.Int(1), PushedAt: &github.Timestamp{Time: now}},
+		}
+	}
+
+	sorts := []struct {
+		field     string
+		direction string
+		expected  []string
+	}{
+		{"stars", "", []string{"acme/Alpha", "acme/beta", "acme/gamma"}},
+		{"stars", "asc", []string{"acme/gamma", "acme/beta", "acme/Alpha"}},
+		{"pushed", "", []string{"acme/gamma", "acme/beta", "acme/Alpha"}},
+		{"name", "", []string{"acme/Alpha", "acme/beta", "acme/gamma"}},
+		{"name", "desc", []string{"acme/gamma", "acme/beta", "acme/Alpha"}},
+		{"", "", []string{"acme/beta", "acme/Alpha", "acme/gamma"}},
+	}
+
+	for _, s := range sorts {
+		repos := newRepos()
+		if err := SortRepos(repos, s.field, s.direction); err != nil {
+			t.Fatalf(expectedNoError, err.Error())
+		}
+
+		for i, repo := range repos {
+			if repo.GetFullName() != s.expected[i] {
+				t.Errorf("expected sorting by '%s' '%s' to give %v, but got '%s' at %d", s.field, s.direction, s.expected, repo.GetFullName(), i)
+				break
+			}
+		}
+	}
+}
+
+func TestSortReposWithInvalidField(t *testing.T) {
+	err := SortRepos(nil, "size", "")
+
+	expectedError := "invalid sort field 'size', use stars, pushed, updated or name"
+	if err == nil {
+		t.Error(expectedErrorGotNil)
+	} else if err.Error() != expectedError {
+		t.Errorf(expectedDifferentError, expectedError, err.Error())
+	}
+
+	err = SortRepos(nil, "stars", "up")
+
+	expectedError = "invalid direction 'up', use asc or desc"
+	if err == nil {
+		t.Error(expectedErrorGotNil)
+	} else if err.Error() != expectedError {
+		t.Errorf(expectedDifferentError, expectedError, err.Error())
+	}
+}
+
+func TestListReposSortsBeforeTruncating(t *testing.T) {
+	pages := [][]*github.Repository{
+		{{FullName: github.String("acme/a"), StargazersCount: github.Int(1)}},
+		{{FullName: github.String("acme/b"), StargazersCount: github.Int(5)}},
+	}
+
+	repos, err := listRepos(1, RepoFilter{Sort: "stars"}, false, func(page github.ListOptions) ([]*github.Repository, *github.Response, error) {
+		resp := &github.Response{}
+		if page.Page < len(pages) {
+			resp.NextPage = page.Page + 1
+		}
+		return pages[page.Page-1], resp, nil
+	})
+
+	if err != nil {
+		t.Errorf(expectedNoError, err.Error())
+	}
+
+	if len(repos) != 1 || repos[0].GetFullName() != "acme/b" {
+		t.Errorf("expected the most starred repository, but got %v", repos)
+	}
+}
+
+func TestListReposWithNegativeSize(t *testing.T) {
+	repos, err := listRepos(-1, RepoFilter{Sort: "stars"}, false, func(page github.ListOptions) ([]*github.Repository, *github.Response, error) {
+		return []*github.Repository{{FullName: github.String("acme/a")}}, &github.Response{}, nil
+	})
+
+	if err != nil {
+		t.Errorf(expectedNoError, err.Error())
+	}
+
+	if len(repos) != 0 {
+		t.Errorf("expected no repositories, but got %v", repos)
+	}
+}
+
+func TestRepoFilterServerSort(t *testing.T) {
+	sorts := []struct {
+		filter    RepoFilter
+		sort      string
+		direction string
+		ok        bool
+	}{
+		{RepoFilter{Sort: "name"}, "full_name", "asc", true},
+		{RepoFilter{Sort: "pushed"}, "pushed", "desc", true},
+		{RepoFilter{Sort: "updated", Direction: "asc"}, "updated", "asc", true},
+		{RepoFilter{Sort: "stars"}, "", "", false},
+	}
+
+	for _, s := range sorts {
+		sort, direction, ok := s.filter.serverSort()
+		if sort != s.sort || direction != s.direction || ok != s.ok {
+			t.Errorf("expected %v to sort by '%s' '%s' %t, but got '%s' '%s' %t", s.filter, s.sort, s.direction, s.ok, sort, direction, ok)
+		}
+	}
+}
+
+func TestRepoFilterListOptions(t *testing.T) {
+	options, err := RepoFilter{Type: "member", Visibility: "private"}.listOptions(true)
+	if err != nil {
+		t.Fatalf(expectedNoError, err.Error())
+	}
+	if options.Affiliation != "organization_member" || options.Visibility != "private" {
+		t.Errorf(expectedDifferentError, "organization_member private", options.Affiliation+" "+options.Visibility)
+	}
+
+	options, err = RepoFilter{Visibility: "public"}.listOptions(true)
+	if err != nil {
+		t.Fatalf(expectedNoError, err.Error())
+	}
+	if options.Affiliation != "owner" {
+		t.Errorf(expectedDifferentError, "owner", options.Affiliation)
+	}
+
+	options, err = RepoFilter{Type: "member", Visibility: "public"}.listOptions(false)
+	if err != nil {
+		t.Fatalf(expectedNoError, err.Error())
+	}
+	if options.Type != "member" || options.Visibility != "" || options.Affiliation != "" {
+		t.Errorf("expected only the type to be sent for other users, but got %+v", options)
+	}
+
+	_, err = RepoFilter{Type: "collaborator"}.listOptions(false)
+
+	expectedError := "collaborator repositories can only be listed for the authenticated user"
+	if err == nil {
+		t.Error(expectedErrorGotNil)
+	} else if err.Error() != expectedError {
+		t.Errorf(expectedDifferentError, expectedError, err.Error())
+	}
+}
+
+func TestRepoFilterApply(t *testing.T) {
+	repos := []*github.Repository{
+		{Name: github.String("api"), Language: github.String("Go"), Topics: []string{"cli", "github"}, Visibility: github.String("public")},
+		{Name: github.String("old-api"), Language: github.String("Go"), Archived: github.Bool(true), Visibility: github.String("public")},
+		{Name: github.String("go-fork"), Language: github.String("Go"), Fork: github.Bool(true), Topics: []string{"cli"}, Visibility: github.String("public")},
+		{Name: github.String("site"), Language: github.String("TypeScript"), Visibility: github.String("private")},
+	}
+
+	filters := []struct {
+		filter   RepoFilter
+		expected []string
+	}{
+		{RepoFilter{}, []string{"api", "old-api", "go-fork", "site"}},
+		{RepoFilter{Language: "go", NoArchived: true}, []string{"api", "go-fork"}},
+		{RepoFilter{Topic: "CLI", Source: true}, []string{"api"}},
+		{RepoFilter{Fork: true}, []string{"go-fork"}},
+		{RepoFilter{Visibility: "private"}, []string{"site"}},
+	}
+
+	for _, f := range filters {
+		filtered := f.filter.apply(repos)
+
+		var names []string
+		for _, repo := range filtered {
+			names = append(names, repo.GetName())
+		}
+
+		if len(names) != len(f.expected) {
+			t.Errorf("expected %+v to keep %v, but got %v", f.filter, f.expected, names)
+			continue
+		}
+		for i := range names {
+			if names[i] != f.expected[i] {
+				t.Errorf("expected %+v to keep %v, but got %v", f.filter, f.expected, names)
+				break
+			}
+		}
+	}
+}
+
+func TestGetTeamReposWithInvalidTeam(t *testing.T) {
+	for _, team := range []string{"platform", "acme/", "acme/platform/infra"} {
+		_, err := GetTeamRepos(team, 30, RepoFilter{})
+
+		expectedError := "invalid team '" + team + "', use the <org/team> format"
+		if err == nil {
+			t.Error(expectedErrorGotNil)
+		} else if err.Error() != expectedError {
+			t.Errorf(expectedDifferentError, expectedError, err.Error())
+		}
+	}
+}
+
+func TestLanguageShares(t *testing.T) {
+	shares := languageShares(map[string]int{"Shell": 250, "Go": 750})
+
+	if len(shares) != 2 || shares[0].Name != "Go" || shares[0].Percent != 75 || shares[1].Name != "Shell" || shares[1].Percent != 25 {
+		t.Errorf("expected Go 75%% and Shell 25%%, got %+v", shares)
+	}
+}
@@ -0,0 +1,190 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/google/go-github/v55/github"
+)
+
+const reviewsBatchSize = 50
+
+const reviewFieldsFragment = `fragment reviewFields on PullRequest {
+  number
+  reviewDecision
+  latestOpinionatedReviews(first: 100) {
+    nodes { state author { login } }
+  }
+  reviewThreads(first: 100) {
+    nodes { isResolved }
+  }
+}`
+
+type reviewFields struct {
+	Number                   int     `json:"number"`
+	ReviewDecision           *string `json:"reviewDecision"`
+	LatestOpinionatedReviews struct {
+		Nodes []struct {
+			State  string `json:"state"`
+			Author struct {
+				Login string `json:"login"`
+			} `json:"author"`
+		} `json:"nodes"`
+	} `json:"latestOpinionatedReviews"`
+	ReviewThreads struct {
+		Nodes []struct {
+			IsResolved bool `json:"isResolved"`
+		} `json:"nodes"`
+	} `json:"reviewThreads"`
+}
+
+type reviewsData struct {
+	Repository map[string]json.RawMessage `json:"repository"`
+}
+
+func AddPRReviews(repoPath string, prs []*PRWithStatus) error {
+	client, err := getClientInstance()
+	if err != nil {
+		return err
+	}
+
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return err
+	}
+
+	// review details are batched into a single query per chunk of pull requests
+	for start := 0; start < len(prs); start += reviewsBatchSize {
+		end := start + reviewsBatchSize
+		if end > len(prs) {
+			end = len(prs)
+		}
+		batch := prs[start:end]
+
+		var data reviewsData
+		variables := map[string]interface{}{"owner": owner, "repo": repo}
+		if err := graphqlQuery(client, reviewsQuery(batch), variables, &data); err != nil {
+			if isSchemaError(err) {
+				return err
+			}
+			return fmt.Errorf("could not retrieve reviews for pull requests in '%s', make sure the repository exists and GITHUB_ACCESS_TOKEN is set and valid", repoPath)
+		}
+
+		for _, pr := range batch {
+			var fields reviewFields
+			raw, ok := data.Repository[fmt.Sprintf("pr%d", pr.PR.GetNumber())]
+			if !ok || json.Unmarshal(raw, &fields) != nil {
+				continue
+			}
+			applyReviewFields(pr, &fields)
+		}
+	}
+
+	return nil
+}
+
+// addPRReviewsREST fills in the review decision and approvals of pull requests
+// over REST, for servers without the GraphQL review fields. REST does not tell
+// whether review threads are resolved, so none are counted as unresolved.
+func addPRReviewsREST(repoPath string, prs []*PRWithStatus) error {
+	client, err := getClientInstance()
+	if err != nil {
+		return err
+	}
+
+	for _, pr := range prs {
+		reviews, err := listPRReviews(client, repoPath, pr.PR.GetNumber())
+		if err != nil {
+			return err
+		}
+
+		pr.RequestedReviewers = nil
+		pr.RequestedTeams = nil
+		for _, reviewer := range pr.PR.RequestedReviewers {
+			pr.RequestedReviewers = append(pr.RequestedReviewers, reviewer.GetLogin())
+		}
+		for _, team := range pr.PR.RequestedTeams {
+			pr.RequestedTeams = append(pr.RequestedTeams, team.GetSlug())
+		}
+
+		pr.Approvals = 0
+		for _, state := range latestReviews(reviews) {
+			if state == "APPROVED" {
+				pr.Approvals++
+			}
+		}
+		pr.UnresolvedThreads = 0
+		pr.ReviewDecision = reviewDecision(reviews, len(pr.RequestedReviewers)+len(pr.RequestedTeams))
+	}
+
+	return nil
+}
+
+func listPRReviews(client *github.Client, repoPath string, number int) ([]*github.PullRequestReview, error) {
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return paginate(math.MaxInt, false, func(page github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+		res, resp, err := client.PullRequests.ListReviews(context.Background(), owner, repo, number, &page)
+		if err != nil {
+			msg := fmt.Errorf("could not retrieve reviews for pull request #%d in '%s', make sure the repository exists and GITHUB_ACCESS_TOKEN is set and valid", number, repoPath)
+			return nil, nil, msg
+		}
+		return res, resp, nil
+	})
+}
+
+func reviewsQuery(prs []*PRWithStatus) string {
+	var query strings.Builder
+
+	query.WriteString("query($owner: String!, $repo: String!) {\n  repository(owner: $owner, name: $repo) {\n")
+	for _, pr := range prs {
+		query.WriteString(fmt.Sprintf("    pr%d: pullRequest(number: %d) { ...reviewFields }\n", pr.PR.GetNumber(), pr.PR.GetNumber()))
+	}
+	query.WriteString("  }\n}\n")
+	query.WriteString(reviewFieldsFragment)
+
+	return query.String()
+}
+
+func applyReviewFields(pr *PRWithStatus, fields *reviewFields) {
+	pr.RequestedReviewers = nil
+	pr.RequestedTeams = nil
+	for _, reviewer := range pr.PR.RequestedReviewers {
+		pr.RequestedReviewers = append(pr.RequestedReviewers, reviewer.GetLogin())
+	}
+	for _, team := range pr.PR.RequestedTeams {
+		pr.RequestedTeams = append(pr.RequestedTeams, team.GetSlug())
+	}
+
+	var reviews []*github.PullRequestReview
+	pr.Approvals = 0
+	for _, review := range fields.LatestOpinionatedReviews.Nodes {
+		if review.State == "APPROVED" {
+			pr.Approvals++
+		}
+		reviews = append(reviews, &github.PullRequestReview{
+			User:  &github.User{Login: github.String(review.Author.Login)},
+			State: github.String(review.State),
+		})
+	}
+
+	pr.UnresolvedThreads = 0
+	for _, thread := range fields.ReviewThreads.Nodes {
+		if !thread.IsResolved {
+			pr.UnresolvedThreads++
+		}
+	}
+
+	if fields.ReviewDecision != nil {
+		pr.ReviewDecision = strings.ToLower(*fields.ReviewDecision)
+	} else {
+		// repositories without required reviews report no decision
+		pr.ReviewDecision = reviewDecision(reviews, len(pr.RequestedReviewers)+len(pr.RequestedTeams))
+	}
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v55/github"
+)
+
+func TestReviewsQueryAliasesEachPR(t *testing.T) {
+	prs := []*PRWithStatus{
+		{PR: &github.PullRequest{Number: github.Int(1)}},
+		{PR: &github.PullRequest{Number: github.Int(42)}},
+	}
+
+	query := reviewsQuery(prs)
+
+	for _, alias := range []string{"pr1: pullRequest(number: 1)", "pr42: pullRequest(number: 42)", "fragment reviewFields"} {
+		if !strings.Contains(query, alias) {
+			t.Errorf("expected query to contain '%s', but got '%s'", alias, query)
+		}
+	}
+}
+
+func TestApplyReviewFields(t *testing.T) {
+	pr := &PRWithStatus{PR: &github.PullRequest{
+		Number:             github.Int(1),
+		RequestedReviewers: []*github.User{{Login: github.String("carol")}},
+		RequestedTeams:     []*github.Team{{Slug: github.String("core")}},
+	}}
+
+	var fields reviewFields
+	raw := `{"number": 1, "reviewDecision": null,
+		"latestOpinionatedReviews": {"nodes": [{"state": "APPROVED", "author": {"login": "alice"}}]},
+		"reviewThreads": {"nodes": [{"isResolved": false}, {"isResolved": true}]}}`
+	if err := json.Unmarshal([]byte(raw), &fields); err != nil {
+		t.Fatalf(expectedNoError, err.Error())
+	}
+
+	applyReviewFields(pr, &fields)
+
+	if pr.Approvals != 1 {
+		t.Errorf("expected 1 approval, but got %d", pr.Approvals)
+	}
+
+	if pr.UnresolvedThreads != 1 {
+		t.Errorf("expected 1 unresolved thread, but got %d", pr.UnresolvedThreads)
+	}
+
+	if pr.ReviewDecision != "review_required" {
+		t.Errorf("expected decision to be 'review_required', but got '%s'", pr.ReviewDecision)
+	}
+
+	if len(pr.RequestedReviewers) != 1 || len(pr.RequestedTeams) != 1 {
+		t.Errorf("expected one requested reviewer and team, but got %v and %v", pr.RequestedReviewers, pr.RequestedTeams)
+	}
+}
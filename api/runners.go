@@ -0,0 +1,205 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v55/github"
+)
+
+type RunnerInfo struct {
+	Runner *github.Runner
+	Group  string
+}
+
+func (r *RunnerInfo) Labels() []string {
+	var labels []string
+	for _, label := range r.Runner.Labels {
+		labels = append(labels, label.GetName())
+	}
+
+	return labels
+}
+
+func ListRunners(scope Scope) ([]*RunnerInfo, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := resolveRunnerScope(client, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := fmt.Errorf("could not retrieve runners for %s, make sure you have admin access and GITHUB_ACCESS_TOKEN is set and valid", scope)
+
+	var runners []*github.Runner
+	options := github.ListOptions{PerPage: pageSizeMax}
+	for {
+		var res *github.Runners
+		var resp *github.Response
+		if scope.Org != "" {
+			res, resp, err = client.Actions.ListOrganizationRunners(context.Background(), scope.Org, &options)
+		} else {
+			res, resp, err = client.Actions.ListRunners(context.Background(), resolved.owner, resolved.repo, &options)
+		}
+		if err != nil {
+			return nil, msg
+		}
+
+		runners = append(runners, res.Runners...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+
+	groups := make(map[int64]string)
+	if scope.Org != "" {
+		groups, err = getRunnerGroups(client, scope.Org)
+		if err != nil {
+			return nil, msg
+		}
+	}
+
+	var infos []*RunnerInfo
+	for _, runner := range runners {
+		group, ok := groups[runner.GetID()]
+		if !ok {
+			group = "-"
+		}
+		infos = append(infos, &RunnerInfo{Runner: runner, Group: group})
+	}
+
+	sort.SliceStable(infos, func(i, j int) bool {
+		return infos[i].Runner.GetName() < infos[j].Runner.GetName()
+	})
+
+	return infos, nil
+}
+
+// getRunnerGroups maps each runner of an organization to its group, since the
+// runner endpoints do not include it.
+func getRunnerGroups(client *github.Client, org string) (map[int64]string, error) {
+	groups := make(map[int64]string)
+
+	groupOptions := github.ListOrgRunnerGroupOptions{ListOptions: github.ListOptions{PerPage: pageSizeMax}}
+	for {
+		res, resp, err := client.Actions.ListOrganizationRunnerGroups(context.Background(), org, &groupOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, group := range res.RunnerGroups {
+			options := github.ListOptions{PerPage: pageSizeMax}
+			for {
+				runners, runnersResp, err := client.Actions.ListRunnerGroupRunners(context.Background(), org, group.GetID(), &options)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, runner := range runners.Runners {
+					groups[runner.GetID()] = group.GetName()
+				}
+
+				if runnersResp.NextPage == 0 {
+					break
+				}
+				options.Page = runnersResp.NextPage
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		groupOptions.Page = resp.NextPage
+	}
+
+	return groups, nil
+}
+
+func GetRunner(scope Scope, selector string) (*RunnerInfo, error) {
+	runners, err := ListRunners(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	return findRunner(runners, selector)
+}
+
+func findRunner(runners []*RunnerInfo, selector string) (*RunnerInfo, error) {
+	for _, runner := range runners {
+		if strconv.FormatInt(runner.Runner.GetID(), 10) == selector || strings.EqualFold(runner.Runner.GetName(), selector) {
+			return runner, nil
+		}
+	}
+
+	return nil, fmt.Errorf("could not find runner '%s'", selector)
+}
+
+func RemoveRunner(scope Scope, selector string) (*RunnerInfo, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := resolveRunnerScope(client, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	runner, err := GetRunner(scope, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	if scope.Org != "" {
+		_, err = client.Actions.RemoveOrganizationRunner(context.Background(), scope.Org, runner.Runner.GetID())
+	} else {
+		_, err = client.Actions.RemoveRunner(context.Background(), resolved.owner, resolved.repo, runner.Runner.GetID())
+	}
+	if err != nil {
+		msg := fmt.Errorf("could not remove runner '%s' from %s, make sure you have admin access and GITHUB_ACCESS_TOKEN is set and valid", runner.Runner.GetName(), scope)
+		return nil, msg
+	}
+
+	return runner, nil
+}
+
+func CreateRunnerRegistrationToken(scope Scope) (*github.RegistrationToken, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := resolveRunnerScope(client, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	var token *github.RegistrationToken
+	if scope.Org != "" {
+		token, _, err = client.Actions.CreateOrganizationRegistrationToken(context.Background(), scope.Org)
+	} else {
+		token, _, err = client.Actions.CreateRegistrationToken(context.Background(), resolved.owner, resolved.repo)
+	}
+	if err != nil {
+		msg := fmt.Errorf("could not create a runner registration token for %s, make sure you have admin access and GITHUB_ACCESS_TOKEN is set and valid", scope)
+		return nil, msg
+	}
+
+	return token, nil
+}
+
+func resolveRunnerScope(client *github.Client, scope Scope) (*resolvedScope, error) {
+	if scope.Env != "" {
+		return nil, fmt.Errorf("runners cannot be scoped to an environment")
+	}
+
+	return resolveScope(client, scope)
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v55/github"
+)
+
+func TestFindRunner(t *testing.T) {
+	runners := []*RunnerInfo{
+		{Runner: &github.Runner{ID: github.Int64(21), Name: github.String("build-01")}},
+		{Runner: &github.Runner{ID: github.Int64(22), Name: github.String("build-02")}},
+	}
+
+	for _, selector := range []string{"22", "BUILD-02"} {
+		runner, err := findRunner(runners, selector)
+		if err != nil {
+			t.Errorf(expectedNoError, err.Error())
+		} else if runner.Runner.GetID() != 22 {
+			t.Errorf("expected '%s' to resolve to runner 22, but got %d", selector, runner.Runner.GetID())
+		}
+	}
+
+	_, err := findRunner(runners, "build-03")
+
+	expectedError := "could not find runner 'build-03'"
+	if err == nil {
+		t.Error(expectedErrorGotNil)
+	} else if err.Error() != expectedError {
+		t.Errorf(expectedDifferentError, expectedError, err.Error())
+	}
+}
+
+func TestRunnerLabels(t *testing.T) {
+	runner := &RunnerInfo{Runner: &github.Runner{Labels: []*github.RunnerLabels{
+		{Name: github.String("self-hosted")},
+		{Name: github.String("linux")},
+		{Name: github.String("gpu")},
+	}}}
+
+	labels := runner.Labels()
+	if len(labels) != 3 || labels[0] != "self-hosted" || labels[2] != "gpu" {
+		t.Errorf(expectedDifferentError, "self-hosted linux gpu", labels)
+	}
+}
@@ -0,0 +1,382 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+)
+
+// downloadClient fetches the signed log and artifact URLs, which must not get
+// the token of the API client. The timeout covers reading the whole body.
+var downloadClient = &http.Client{Timeout: 10 * time.Minute}
+
+type RunFilter struct {
+	Workflow string
+	Branch   string
+	Event    string
+	Actor    string
+	Status   string
+}
+
+type JobLog struct {
+	Name    string
+	Content []byte
+}
+
+func ListRuns(repoPath string, filter RunFilter, size int) ([]*github.WorkflowRun, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var workflowID int64
+	if filter.Workflow != "" {
+		workflow, err := GetWorkflow(repoPath, filter.Workflow)
+		if err != nil {
+			return nil, err
+		}
+		workflowID = workflow.GetID()
+	}
+
+	return paginate(size, false, func(page github.ListOptions) ([]*github.WorkflowRun, *github.Response, error) {
+		options := github.ListWorkflowRunsOptions{
+			Actor:       filter.Actor,
+			Branch:      filter.Branch,
+			Event:       filter.Event,
+			Status:      filter.Status,
+			ListOptions: page,
+		}
+
+		var res *github.WorkflowRuns
+		var resp *github.Response
+		var err error
+		if workflowID != 0 {
+			res, resp, err = client.Actions.ListWorkflowRunsByID(context.Background(), owner, repo, workflowID, &options)
+		} else {
+			res, resp, err = client.Actions.ListRepositoryWorkflowRuns(context.Background(), owner, repo, &options)
+		}
+		if err != nil {
+			msg := fmt.Errorf("could not retrieve workflow runs for repo '%s', make sure the repository exists and GITHUB_ACCESS_TOKEN is set and valid", repoPath)
+			return nil, nil, msg
+		}
+
+		return res.WorkflowRuns, resp, nil
+	})
+}
+
+func GetRun(repoPath string, id int64) (*github.WorkflowRun, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	run, _, err := client.Actions.GetWorkflowRunByID(context.Background(), owner, repo, id)
+	if err != nil {
+		msg := fmt.Errorf("could not retrieve workflow run %d in '%s', make sure the run exists and GITHUB_ACCESS_TOKEN is set and valid", id, repoPath)
+		return nil, msg
+	}
+
+	return run, nil
+}
+
+func ListRunJobs(repoPath string, id int64) ([]*github.WorkflowJob, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := listRunJobs(client, owner, repo, id, "latest")
+	if err != nil {
+		msg := fmt.Errorf("could not retrieve jobs for workflow run %d in '%s', make sure the run exists and GITHUB_ACCESS_TOKEN is set and valid", id, repoPath)
+		return nil, msg
+	}
+
+	return jobs, nil
+}
+
+// listRunJobs lists the jobs of the latest attempt of a run, or of every attempt
+// when filter is "all".
+func listRunJobs(client *github.Client, owner string, repo string, id int64, filter string) ([]*github.WorkflowJob, error) {
+	return paginate(math.MaxInt, false, func(page github.ListOptions) ([]*github.WorkflowJob, *github.Response, error) {
+		options := github.ListWorkflowJobsOptions{Filter: filter, ListOptions: page}
+		res, resp, err := client.Actions.ListWorkflowJobs(context.Background(), owner, repo, id, &options)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return res.Jobs, resp, nil
+	})
+}
+
+func RerunRun(repoPath string, id int64, failedOnly bool, debug bool) error {
+	client, err := getClientInstance()
+	if err != nil {
+		return err
+	}
+
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/actions/runs/%d/rerun", owner, repo, id)
+	if failedOnly {
+		path += "-failed-jobs"
+	}
+
+	msg := fmt.Errorf("could not re-run workflow run %d in '%s', make sure the run has completed and GITHUB_ACCESS_TOKEN is set and valid", id, repoPath)
+
+	// the typed rerun methods do not accept a body, so debug logging needs a raw request
+	req, err := client.NewRequest("POST", path, map[string]bool{"enable_debug_logging": debug})
+	if err != nil {
+		return msg
+	}
+
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		return msg
+	}
+
+	return nil
+}
+
+func CancelRun(repoPath string, id int64) error {
+	client, err := getClientInstance()
+	if err != nil {
+		return err
+	}
+
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Actions.CancelWorkflowRunByID(context.Background(), owner, repo, id)
+
+	// the cancellation is queued with a 202, which go-github reports as an error
+	var accepted *github.AcceptedError
+	if err != nil && !errors.As(err, &accepted) {
+		msg := fmt.Errorf("could not cancel workflow run %d in '%s', make sure the run is in progress and GITHUB_ACCESS_TOKEN is set and valid", id, repoPath)
+		return msg
+	}
+
+	return nil
+}
+
+func GetRunLogs(repoPath string, id int64) (*zip.Reader, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := fmt.Errorf("could not retrieve logs for workflow run %d in '%s', make sure the run exists and GITHUB_ACCESS_TOKEN is set and valid", id, repoPath)
+
+	logsURL, _, err := client.Actions.GetWorkflowRunLogs(context.Background(), owner, repo, id, true)
+	if err != nil {
+		return nil, msg
+	}
+
+	archive, err := downloadZip(logsURL)
+	if err != nil {
+		return nil, msg
+	}
+
+	return archive, nil
+}
+
+func GetJobLogs(repoPath string, jobID int64) (io.ReadCloser, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := fmt.Errorf("could not retrieve logs for job %d in '%s', make sure the job exists and GITHUB_ACCESS_TOKEN is set and valid", jobID, repoPath)
+
+	logsURL, _, err := client.Actions.GetWorkflowJobLogs(context.Background(), owner, repo, jobID, true)
+	if err != nil {
+		return nil, msg
+	}
+
+	res, err := downloadClient.Get(logsURL.String())
+	if err != nil {
+		return nil, msg
+	}
+
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, msg
+	}
+
+	return res.Body, nil
+}
+
+func ListRunArtifacts(repoPath string, id int64) ([]*github.Artifact, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return paginate(math.MaxInt, false, func(page github.ListOptions) ([]*github.Artifact, *github.Response, error) {
+		res, resp, err := client.Actions.ListWorkflowRunArtifacts(context.Background(), owner, repo, id, &page)
+		if err != nil {
+			msg := fmt.Errorf("could not retrieve artifacts for workflow run %d in '%s', make sure the run exists and GITHUB_ACCESS_TOKEN is set and valid", id, repoPath)
+			return nil, nil, msg
+		}
+
+		return res.Artifacts, resp, nil
+	})
+}
+
+func DownloadArtifact(repoPath string, artifact *github.Artifact) (*zip.Reader, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := fmt.Errorf("could not download artifact '%s' in '%s', make sure it has not expired and GITHUB_ACCESS_TOKEN is set and valid", artifact.GetName(), repoPath)
+
+	artifactURL, _, err := client.Actions.DownloadArtifact(context.Background(), owner, repo, artifact.GetID(), true)
+	if err != nil {
+		return nil, msg
+	}
+
+	archive, err := downloadZip(artifactURL)
+	if err != nil {
+		return nil, msg
+	}
+
+	return archive, nil
+}
+
+// downloadZip fetches an archive from a pre-signed URL, which must be requested
+// without the GitHub credentials.
+func downloadZip(archiveURL *url.URL) (*zip.Reader, error) {
+	res, err := downloadClient.Get(archiveURL.String())
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download failed with status %d", res.StatusCode)
+	}
+
+	content, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return zip.NewReader(bytes.NewReader(content), int64(len(content)))
+}
+
+// JobLogs returns the log of each job in a run's log archive. The archive holds
+// one complete log per job at its root, next to a directory of per-step logs.
+func JobLogs(archive *zip.Reader) ([]*JobLog, error) {
+	var logs []*JobLog
+
+	for _, file := range archive.File {
+		if strings.Contains(file.Name, "/") || !strings.HasSuffix(file.Name, ".txt") {
+			continue
+		}
+
+		content, err := readZipFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		logs = append(logs, &JobLog{Name: strings.TrimSuffix(file.Name, ".txt"), Content: content})
+	}
+
+	return logs, nil
+}
+
+func ExtractZip(archive *zip.Reader, dir string) error {
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range archive.File {
+		target := filepath.Join(root, file.Name)
+		if target != root && !strings.HasPrefix(target, root+string(os.PathSeparator)) {
+			return fmt.Errorf("archive entry '%s' is outside of the target directory", file.Name)
+		}
+
+		if file.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+
+		content, err := readZipFile(file)
+		if err != nil {
+			return err
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, content, 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newZip(t *testing.T, files map[string]string) *zip.Reader {
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+
+	for name, content := range files {
+		file, err := writer.Create(name)
+		if err != nil {
+			t.Fatalf(expectedNoError, err.Error())
+		}
+		file.Write([]byte(content))
+	}
+	writer.Close()
+
+	reader, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatalf(expectedNoError, err.Error())
+	}
+
+	return reader
+}
+
+func TestJobLogsOnlyReturnsJobFiles(t *testing.T) {
+	archive := newZip(t, map[string]string{
+		"0_build.txt":              "build log",
+		"build/1_Set up job.txt":   "step log",
+		"build/2_Run go build.txt": "step log",
+	})
+
+	logs, err := JobLogs(archive)
+	if err != nil {
+		t.Fatalf(expectedNoError, err.Error())
+	}
+
+	if len(logs) != 1 {
+		t.Fatalf("expected 1 job log, but got %d", len(logs))
+	}
+
+	if logs[0].Name != "0_build" || string(logs[0].Content) != "build log" {
+		t.Errorf("expected the build job log, but got '%s': '%s'", logs[0].Name, logs[0].Content)
+	}
+}
+
+func TestExtractZip(t *testing.T) {
+	dir := t.TempDir()
+	archive := newZip(t, map[string]string{"build/1_Set up job.txt": "step log"})
+
+	if err := ExtractZip(archive, dir); err != nil {
+		t.Fatalf(expectedNoError, err.Error())
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "build", "1_Set up job.txt"))
+	if err != nil {
+		t.Fatalf(expectedNoError, err.Error())
+	}
+
+	if string(content) != "step log" {
+		t.Errorf("expected extracted content to be 'step log', but got '%s'", content)
+	}
+}
+
+func TestExtractZipWithEntryOutsideOfDir(t *testing.T) {
+	archive := newZip(t, map[string]string{"../escape.txt": "nope"})
+
+	err := ExtractZip(archive, t.TempDir())
+
+	expectedError := "archive entry '../escape.txt' is outside of the target directory"
+	if err == nil {
+		t.Error(expectedErrorGotNil)
+	} else if err.Error() != expectedError {
+		t.Errorf(expectedDifferentError, expectedError, err.Error())
+	}
+}
@@ -0,0 +1,470 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-github/v55/github"
+	"golang.org/x/crypto/nacl/box"
+)
+
+// Scope selects where secrets and variables live: a repository, one of its
+// environments, or an organization.
+type Scope struct {
+	Repo string
+	Env  string
+	Org  string
+}
+
+func (s Scope) String() string {
+	switch {
+	case s.Org != "":
+		return fmt.Sprintf("organization '%s'", s.Org)
+	case s.Env != "":
+		return fmt.Sprintf("environment '%s' of '%s'", s.Env, s.Repo)
+	}
+
+	return fmt.Sprintf("repo '%s'", s.Repo)
+}
+
+// OrgVisibility is which repositories of an organization can use a secret or
+// variable. An empty Visibility keeps the current one, and makes new secrets
+// and variables private. Repos lists the repositories when it is selected.
+type OrgVisibility struct {
+	Visibility string
+	Repos      []string
+}
+
+var secretNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type resolvedScope struct {
+	Scope
+	owner  string
+	repo   string
+	repoID int
+}
+
+func resolveScope(client *github.Client, scope Scope) (*resolvedScope, error) {
+	if scope.Org != "" {
+		if scope.Repo != "" || scope.Env != "" {
+			return nil, fmt.Errorf("an organization scope cannot be combined with a repository or environment")
+		}
+		return &resolvedScope{Scope: scope}, nil
+	}
+
+	owner, repo, err := parseRepoPath(scope.Repo)
+	if err != nil {
+		return nil, err
+	}
+	resolved := &resolvedScope{Scope: scope, owner: owner, repo: repo}
+
+	// environment endpoints are addressed by repository ID
+	if scope.Env != "" {
+		repository, _, err := client.Repositories.Get(context.Background(), owner, repo)
+		if err != nil {
+			msg := fmt.Errorf("could not retrieve repository '%s', make sure the repository exists and GITHUB_ACCESS_TOKEN is set and valid", scope.Repo)
+			return nil, msg
+		}
+		resolved.repoID = int(repository.GetID())
+	}
+
+	return resolved, nil
+}
+
+func ListSecrets(scope Scope) ([]*github.Secret, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := resolveScope(client, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	var secrets []*github.Secret
+	options := github.ListOptions{PerPage: pageSizeMax}
+	for {
+		var res *github.Secrets
+		var resp *github.Response
+		switch {
+		case scope.Org != "":
+			res, resp, err = client.Actions.ListOrgSecrets(context.Background(), scope.Org, &options)
+		case scope.Env != "":
+			res, resp, err = client.Actions.ListEnvSecrets(context.Background(), resolved.repoID, scope.Env, &options)
+		default:
+			res, resp, err = client.Actions.ListRepoSecrets(context.Background(), resolved.owner, resolved.repo, &options)
+		}
+		if err != nil {
+			msg := fmt.Errorf("could not retrieve secrets for %s, make sure it exists and GITHUB_ACCESS_TOKEN is set and valid", scope)
+			return nil, msg
+		}
+
+		secrets = append(secrets, res.Secrets...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+
+	return secrets, nil
+}
+
+// SetSecrets encrypts each value against the scope's public key and creates or
+// updates the secrets. Visibility only applies to organization secrets.
+func SetSecrets(scope Scope, values map[string]string, visibility OrgVisibility) error {
+	if err := validateNames(values); err != nil {
+		return err
+	}
+
+	client, err := getClientInstance()
+	if err != nil {
+		return err
+	}
+
+	resolved, err := resolveScope(client, scope)
+	if err != nil {
+		return err
+	}
+
+	var key *github.PublicKey
+	switch {
+	case scope.Org != "":
+		key, _, err = client.Actions.GetOrgPublicKey(context.Background(), scope.Org)
+	case scope.Env != "":
+		key, _, err = client.Actions.GetEnvPublicKey(context.Background(), resolved.repoID, scope.Env)
+	default:
+		key, _, err = client.Actions.GetRepoPublicKey(context.Background(), resolved.owner, resolved.repo)
+	}
+	if err != nil {
+		msg := fmt.Errorf("could not retrieve the public key for %s, make sure it exists and GITHUB_ACCESS_TOKEN is set and valid", scope)
+		return msg
+	}
+
+	var repoIDs github.SelectedRepoIDs
+	if scope.Org != "" && visibility.Visibility == "selected" {
+		repoIDs, err = selectedRepoIDs(client, scope.Org, visibility.Repos)
+		if err != nil {
+			return err
+		}
+	}
+
+	for name, value := range values {
+		encrypted, err := encryptSecret(key.GetKey(), value)
+		if err != nil {
+			return err
+		}
+
+		secret := &github.EncryptedSecret{Name: name, KeyID: key.GetKeyID(), EncryptedValue: encrypted}
+		switch {
+		case scope.Org != "":
+			secret.Visibility, secret.SelectedRepositoryIDs = visibility.Visibility, repoIDs
+			if secret.Visibility == "" {
+				// the visibility is required, so send the current one back
+				secret.Visibility, secret.SelectedRepositoryIDs, err = orgSecretVisibility(client, scope.Org, name)
+				if err != nil {
+					return err
+				}
+			}
+			_, err = client.Actions.CreateOrUpdateOrgSecret(context.Background(), scope.Org, secret)
+		case scope.Env != "":
+			_, err = client.Actions.CreateOrUpdateEnvSecret(context.Background(), resolved.repoID, scope.Env, secret)
+		default:
+			_, err = client.Actions.CreateOrUpdateRepoSecret(context.Background(), resolved.owner, resolved.repo, secret)
+		}
+		if err != nil {
+			msg := fmt.Errorf("could not set secret '%s' for %s, make sure you have admin access and GITHUB_ACCESS_TOKEN is set and valid", name, scope)
+			return msg
+		}
+	}
+
+	return nil
+}
+
+// orgSecretVisibility returns the visibility of an organization secret and its
+// selected repositories, or private when the secret does not exist yet.
+func orgSecretVisibility(client *github.Client, org string, name string) (string, github.SelectedRepoIDs, error) {
+	msg := fmt.Errorf("could not retrieve secret '%s' for organization '%s', make sure you have admin access and GITHUB_ACCESS_TOKEN is set and valid", name, org)
+
+	secret, _, err := client.Actions.GetOrgSecret(context.Background(), org, name)
+	if isNotFound(err) {
+		return "private", nil, nil
+	}
+	if err != nil {
+		return "", nil, msg
+	}
+	if secret.Visibility != "selected" {
+		return secret.Visibility, nil, nil
+	}
+
+	repos, err := paginate(math.MaxInt, false, func(page github.ListOptions) ([]*github.Repository, *github.Response, error) {
+		res, resp, err := client.Actions.ListSelectedReposForOrgSecret(context.Background(), org, name, &page)
+		if err != nil {
+			return nil, nil, msg
+		}
+		return res.Repositories, resp, nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	repoIDs := github.SelectedRepoIDs{}
+	for _, repo := range repos {
+		repoIDs = append(repoIDs, repo.GetID())
+	}
+
+	return secret.Visibility, repoIDs, nil
+}
+
+// selectedRepoIDs looks up the IDs of repositories given as <owner/repo> or as
+// the name of a repository of the organization.
+func selectedRepoIDs(client *github.Client, org string, repos []string) (github.SelectedRepoIDs, error) {
+	repositories, err := lookupOrgRepos(client, org, repos)
+	if err != nil {
+		return nil, err
+	}
+
+	repoIDs := github.SelectedRepoIDs{}
+	for _, repository := range repositories {
+		repoIDs = append(repoIDs, repository.GetID())
+	}
+
+	return repoIDs, nil
+}
+
+// lookupOrgRepos gets repositories given as <owner/repo> or as the name of a
+// repository of the organization.
+func lookupOrgRepos(client *github.Client, org string, repos []string) ([]*github.Repository, error) {
+	var repositories []*github.Repository
+	for _, repoPath := range repos {
+		if !strings.Contains(repoPath, "/") {
+			repoPath = org + "/" + repoPath
+		}
+
+		owner, repo, err := parseRepoPath(repoPath)
+		if err != nil {
+			return nil, err
+		}
+
+		repository, _, err := client.Repositories.Get(context.Background(), owner, repo)
+		if err != nil {
+			msg := fmt.Errorf("could not retrieve repository '%s', make sure the repository exists and GITHUB_ACCESS_TOKEN is set and valid", repoPath)
+			return nil, msg
+		}
+		repositories = append(repositories, repository)
+	}
+
+	return repositories, nil
+}
+
+func DeleteSecret(scope Scope, name string) error {
+	client, err := getClientInstance()
+	if err != nil {
+		return err
+	}
+
+	resolved, err := resolveScope(client, scope)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case scope.Org != "":
+		_, err = client.Actions.DeleteOrgSecret(context.Background(), scope.Org, name)
+	case scope.Env != "":
+		_, err = client.Actions.DeleteEnvSecret(context.Background(), resolved.repoID, scope.Env, name)
+	default:
+		_, err = client.Actions.DeleteRepoSecret(context.Background(), resolved.owner, resolved.repo, name)
+	}
+	if err != nil {
+		msg := fmt.Errorf("could not delete secret '%s' for %s, make sure the secret exists and GITHUB_ACCESS_TOKEN is set and valid", name, scope)
+		return msg
+	}
+
+	return nil
+}
+
+// validateNames checks names against the rules GitHub applies to both secrets
+// and variables, before any of them is set.
+func validateNames(values map[string]string) error {
+	for name := range values {
+		if !secretNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid name '%s', names can only contain letters, digits and underscores and cannot start with a digit", name)
+		}
+		if strings.HasPrefix(strings.ToUpper(name), "GITHUB_") {
+			return fmt.Errorf("invalid name '%s', names cannot start with the GITHUB_ prefix", name)
+		}
+	}
+
+	return nil
+}
+
+// encryptSecret seals the value with libsodium's sealed box construction, which
+// is what GitHub expects for secret values.
+func encryptSecret(publicKey string, value string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil || len(decoded) != 32 {
+		return "", fmt.Errorf("invalid public key '%s'", publicKey)
+	}
+
+	var key [32]byte
+	copy(key[:], decoded)
+
+	sealed, err := box.SealAnonymous(nil, []byte(value), &key, rand.Reader)
+	if err != nil {
+		return "", fmt.Errorf("could not encrypt secret: %s", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func ListVariables(scope Scope) ([]*github.ActionsVariable, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := resolveScope(client, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	return listVariables(client, resolved)
+}
+
+func listVariables(client *github.Client, scope *resolvedScope) ([]*github.ActionsVariable, error) {
+	var variables []*github.ActionsVariable
+	// the variables endpoints return at most 30 items per page
+	options := github.ListOptions{PerPage: 30}
+	for {
+		var res *github.ActionsVariables
+		var resp *github.Response
+		var err error
+		switch {
+		case scope.Org != "":
+			res, resp, err = client.Actions.ListOrgVariables(context.Background(), scope.Org, &options)
+		case scope.Env != "":
+			res, resp, err = client.Actions.ListEnvVariables(context.Background(), scope.repoID, scope.Env, &options)
+		default:
+			res, resp, err = client.Actions.ListRepoVariables(context.Background(), scope.owner, scope.repo, &options)
+		}
+		if err != nil {
+			msg := fmt.Errorf("could not retrieve variables for %s, make sure it exists and GITHUB_ACCESS_TOKEN is set and valid", scope.Scope)
+			return nil, msg
+		}
+
+		variables = append(variables, res.Variables...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+
+	return variables, nil
+}
+
+// SetVariables creates the variables that do not exist yet and updates the
+// others. Visibility only applies to organization variables.
+func SetVariables(scope Scope, values map[string]string, visibility OrgVisibility) error {
+	if err := validateNames(values); err != nil {
+		return err
+	}
+
+	client, err := getClientInstance()
+	if err != nil {
+		return err
+	}
+
+	resolved, err := resolveScope(client, scope)
+	if err != nil {
+		return err
+	}
+
+	existing, err := listVariables(client, resolved)
+	if err != nil {
+		return err
+	}
+	exists := make(map[string]bool)
+	for _, variable := range existing {
+		exists[variable.Name] = true
+	}
+
+	var repoIDs github.SelectedRepoIDs
+	if scope.Org != "" && visibility.Visibility == "selected" {
+		repoIDs, err = selectedRepoIDs(client, scope.Org, visibility.Repos)
+		if err != nil {
+			return err
+		}
+	}
+
+	for name, value := range values {
+		variable := &github.ActionsVariable{Name: name, Value: value}
+		// updates keep the current visibility when none is given
+		switch {
+		case scope.Org == "":
+		case visibility.Visibility != "":
+			variable.Visibility = github.String(visibility.Visibility)
+			if repoIDs != nil {
+				variable.SelectedRepositoryIDs = &repoIDs
+			}
+		case !exists[name]:
+			variable.Visibility = github.String("private")
+		}
+
+		ctx := context.Background()
+		switch {
+		case scope.Org != "" && exists[name]:
+			_, err = client.Actions.UpdateOrgVariable(ctx, scope.Org, variable)
+		case scope.Org != "":
+			_, err = client.Actions.CreateOrgVariable(ctx, scope.Org, variable)
+		case scope.Env != "" && exists[name]:
+			_, err = client.Actions.UpdateEnvVariable(ctx, resolved.repoID, scope.Env, variable)
+		case scope.Env != "":
+			_, err = client.Actions.CreateEnvVariable(ctx, resolved.repoID, scope.Env, variable)
+		case exists[name]:
+			_, err = client.Actions.UpdateRepoVariable(ctx, resolved.owner, resolved.repo, variable)
+		default:
+			_, err = client.Actions.CreateRepoVariable(ctx, resolved.owner, resolved.repo, variable)
+		}
+		if err != nil {
+			msg := fmt.Errorf("could not set variable '%s' for %s, make sure you have admin access and GITHUB_ACCESS_TOKEN is set and valid", name, scope)
+			return msg
+		}
+	}
+
+	return nil
+}
+
+func DeleteVariable(scope Scope, name string) error {
+	client, err := getClientInstance()
+	if err != nil {
+		return err
+	}
+
+	resolved, err := resolveScope(client, scope)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case scope.Org != "":
+		_, err = client.Actions.DeleteOrgVariable(context.Background(), scope.Org, name)
+	case scope.Env != "":
+		_, err = client.Actions.DeleteEnvVariable(context.Background(), resolved.repoID, scope.Env, name)
+	default:
+		_, err = client.Actions.DeleteRepoVariable(context.Background(), resolved.owner, resolved.repo, name)
+	}
+	if err != nil {
+		msg := fmt.Errorf("could not delete variable '%s' for %s, make sure the variable exists and GITHUB_ACCESS_TOKEN is set and valid", name, scope)
+		return msg
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	"golang.org/x/crypto/nacl/box"
+)
+
+func TestEncryptSecret(t *testing.T) {
+	publicKey, privateKey, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf(expectedNoError, err.Error())
+	}
+
+	encrypted, err := encryptSecret(base64.StdEncoding.EncodeToString(publicKey[:]), "hunter2")
+	if err != nil {
+		t.Fatalf(expectedNoError, err.Error())
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		t.Fatalf(expectedNoError, err.Error())
+	}
+
+	opened, ok := box.OpenAnonymous(nil, sealed, publicKey, privateKey)
+	if !ok {
+		t.Fatal("expected the sealed box to open with the matching private key")
+	}
+
+	if string(opened) != "hunter2" {
+		t.Errorf(expectedDifferentError, "hunter2", string(opened))
+	}
+}
+
+func TestEncryptSecretWithInvalidKey(t *testing.T) {
+	_, err := encryptSecret("bm90IGEga2V5", "hunter2")
+
+	expectedError := "invalid public key 'bm90IGEga2V5'"
+	if err == nil {
+		t.Error(expectedErrorGotNil)
+	} else if err.Error() != expectedError {
+		t.Errorf(expectedDifferentError, expectedError, err.Error())
+	}
+}
+
+func TestValidateNames(t *testing.T) {
+	if err := validateNames(map[string]string{"API_TOKEN": "x", "_private2": "y"}); err != nil {
+		t.Errorf(expectedNoError, err.Error())
+	}
+
+	invalid := map[string]string{
+		"2FA_KEY":      "invalid name '2FA_KEY', names can only contain letters, digits and underscores and cannot start with a digit",
+		"API-TOKEN":    "invalid name 'API-TOKEN', names can only contain letters, digits and underscores and cannot start with a digit",
+		"github_token": "invalid name 'github_token', names cannot start with the GITHUB_ prefix",
+	}
+	for name, expectedError := range invalid {
+		err := validateNames(map[string]string{name: "x"})
+		if err == nil {
+			t.Error(expectedErrorGotNil)
+		} else if err.Error() != expectedError {
+			t.Errorf(expectedDifferentError, expectedError, err.Error())
+		}
+	}
+}
+
+func TestScopeString(t *testing.T) {
+	scopes := map[Scope]string{
+		{Repo: "owner/repo"}:                 "repo 'owner/repo'",
+		{Repo: "owner/repo", Env: "staging"}: "environment 'staging' of 'owner/repo'",
+		{Org: "acme"}:                        "organization 'acme'",
+	}
+
+	for scope, expected := range scopes {
+		if scope.String() != expected {
+			t.Errorf(expectedDifferentError, expected, scope.String())
+		}
+	}
+}
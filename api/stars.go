@@ -0,0 +1,189 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/google/go-github/v55/github"
+)
+
+// GetStarredRepos lists the repositories a user starred, sorted like
+// SortStarredRepos. When GitHub cannot sort by the field, every page is
+// fetched before sorting and truncating.
+func GetStarredRepos(username string, size int, filter RepoFilter) ([]*github.StarredRepository, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	options := github.ActivityListStarredOptions{Sort: "created", Direction: filter.Direction}
+	limit := size
+	switch filter.Sort {
+	case "", "starred":
+	case "updated":
+		options.Sort = "updated"
+	default:
+		limit = math.MaxInt
+	}
+	if err := SortStarredRepos(nil, filter.Sort, filter.Direction); err != nil {
+		return nil, err
+	}
+	if size <= 0 {
+		return []*github.StarredRepository{}, nil
+	}
+
+	starred, err := paginate(limit, filter.clientSide(), func(page github.ListOptions) ([]*github.StarredRepository, *github.Response, error) {
+		options.ListOptions = page
+		res, resp, err := client.Activity.ListStarred(context.Background(), username, &options)
+		if err != nil {
+			msg := fmt.Errorf("could not retrieve starred repositories for user '%s', make sure the username is valid and GITHUB_ACCESS_TOKEN is set and valid", username)
+			return nil, nil, msg
+		}
+
+		var starred []*github.StarredRepository
+		for _, star := range res {
+			if filter.matches(star.GetRepository()) {
+				starred = append(starred, star)
+			}
+		}
+
+		return starred, resp, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := SortStarredRepos(starred, filter.Sort, filter.Direction); err != nil {
+		return nil, err
+	}
+	if len(starred) > size {
+		starred = starred[:size]
+	}
+
+	return starred, nil
+}
+
+// SortStarredRepos orders starred repositories in place like SortRepos, and
+// also by when they were starred.
+func SortStarredRepos(starred []*github.StarredRepository, field string, direction string) error {
+	if field == "starred" {
+		if direction != "" && direction != "asc" && direction != "desc" {
+			return fmt.Errorf("invalid direction '%s', use asc or desc", direction)
+		}
+
+		sort.SliceStable(starred, func(i, j int) bool {
+			if direction == "asc" {
+				return starred[i].GetStarredAt().Before(starred[j].GetStarredAt().Time)
+			}
+			return starred[j].GetStarredAt().Before(starred[i].GetStarredAt().Time)
+		})
+		return nil
+	}
+
+	less, ascending, err := repoLess(field, direction)
+	if err != nil || less == nil {
+		return err
+	}
+
+	sort.SliceStable(starred, func(i, j int) bool {
+		if ascending {
+			return less(starred[i].GetRepository(), starred[j].GetRepository())
+		}
+		return less(starred[j].GetRepository(), starred[i].GetRepository())
+	})
+
+	return nil
+}
+
+func StarRepo(repoPath string, star bool) error {
+	client, err := getClientInstance()
+	if err != nil {
+		return err
+	}
+
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return err
+	}
+
+	if star {
+		_, err = client.Activity.Star(context.Background(), owner, repo)
+	} else {
+		_, err = client.Activity.Unstar(context.Background(), owner, repo)
+	}
+	if err != nil {
+		msg := fmt.Errorf("could not update the star on repo '%s', make sure the repository exists and GITHUB_ACCESS_TOKEN is set and valid", repoPath)
+		return msg
+	}
+
+	return nil
+}
+
+// GetWatchLevel returns all when the user watches every activity of the repo,
+// ignore when they never get notified and participating otherwise.
+func GetWatchLevel(repoPath string) (string, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return "", err
+	}
+
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return "", err
+	}
+
+	subscription, _, err := client.Activity.GetRepositorySubscription(context.Background(), owner, repo)
+	if err != nil {
+		msg := fmt.Errorf("could not retrieve the subscription to repo '%s', make sure the repository exists and GITHUB_ACCESS_TOKEN is set and valid", repoPath)
+		return "", msg
+	}
+
+	return watchLevel(subscription), nil
+}
+
+func watchLevel(subscription *github.Subscription) string {
+	switch {
+	case subscription == nil:
+		return "participating"
+	case subscription.GetIgnored():
+		return "ignore"
+	case subscription.GetSubscribed():
+		return "all"
+	}
+
+	return "participating"
+}
+
+func SetWatchLevel(repoPath string, level string) error {
+	var subscription *github.Subscription
+	switch level {
+	case "all":
+		subscription = &github.Subscription{Subscribed: github.Bool(true)}
+	case "ignore":
+		subscription = &github.Subscription{Ignored: github.Bool(true)}
+	case "releases":
+		return fmt.Errorf("the releases watch level cannot be set through the GitHub API, choose Custom and Releases under Watch on https://github.com/%s instead", repoPath)
+	default:
+		return fmt.Errorf("invalid watch level '%s', use all, releases or ignore", level)
+	}
+
+	client, err := getClientInstance()
+	if err != nil {
+		return err
+	}
+
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = client.Activity.SetRepositorySubscription(context.Background(), owner, repo, subscription)
+	if err != nil {
+		msg := fmt.Errorf("could not update the subscription to repo '%s', make sure the repository exists and GITHUB_ACCESS_TOKEN is set and valid", repoPath)
+		return msg
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+)
+
+func TestSortStarredRepos(t *testing.T) {
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	newStarred := func() []*github.StarredRepository {
+		return []*github.StarredRepository{
+			{StarredAt: &github.Timestamp{Time: now.Add(-time.Hour)}, Repository: &github.Repository{FullName: github.String("acme/beta"), StargazersCount: github.Int(5)}},
+			{StarredAt: &github.Timestamp{Time: now}, Repository: &github.Repository{FullName: github.String("acme/alpha"), StargazersCount: github.Int(1)}},
+			{StarredAt: &github.Timestamp{Time: now.Add(-48 * time.Hour)}, Repository: &github.Repository{FullName: github.String("acme/gamma"), StargazersCount: github.Int(50)}},
+		}
+	}
+
+	sorts := []struct {
+		field     string
+		direction string
+		expected  []string
+	}{
+		{"starred", "", []string{"acme/alpha", "acme/beta", "acme/gamma"}},
+		{"starred", "asc", []string{"acme/gamma", "acme/beta", "acme/alpha"}},
+		{"stars", "", []string{"acme/gamma", "acme/beta", "acme/alpha"}},
+		{"name", "", []string{"acme/alpha", "acme/beta", "acme/gamma"}},
+	}
+
+	for _, s := range sorts {
+		starred := newStarred()
+		if err := SortStarredRepos(starred, s.field, s.direction); err != nil {
+			t.Fatalf(expectedNoError, err.Error())
+		}
+
+		for i, star := range starred {
+			if star.GetRepository().GetFullName() != s.expected[i] {
+				t.Errorf("expected sorting by '%s' '%s' to give %v, but got '%s' at %d", s.field, s.direction, s.expected, star.GetRepository().GetFullName(), i)
+				break
+			}
+		}
+	}
+}
+
+func TestWatchLevel(t *testing.T) {
+	levels := []struct {
+		subscription *github.Subscription
+		expected     string
+	}{
+		{nil, "participating"},
+		{&github.Subscription{Subscribed: github.Bool(true)}, "all"},
+		{&github.Subscription{Ignored: github.Bool(true)}, "ignore"},
+		{&github.Subscription{}, "participating"},
+	}
+
+	for _, l := range levels {
+		if level := watchLevel(l.subscription); level != l.expected {
+			t.Errorf(expectedDifferentError, l.expected, level)
+		}
+	}
+}
+
+func TestSetWatchLevelWithUnsupportedLevel(t *testing.T) {
+	err := SetWatchLevel("acme/api", "releases")
+
+	expectedError := "the releases watch level cannot be set through the GitHub API, choose Custom and Releases under Watch on https://github.com/acme/api instead"
+	if err == nil {
+		t.Error(expectedErrorGotNil)
+	} else if err.Error() != expectedError {
+		t.Errorf(expectedDifferentError, expectedError, err.Error())
+	}
+
+	err = SetWatchLevel("acme/api", "mentions")
+
+	expectedError = "invalid watch level 'mentions', use all, releases or ignore"
+	if err == nil {
+		t.Error(expectedErrorGotNil)
+	} else if err.Error() != expectedError {
+		t.Errorf(expectedDifferentError, expectedError, err.Error())
+	}
+}
@@ -0,0 +1,170 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+)
+
+type UsageRow struct {
+	Repo            string
+	Workflow        string
+	OS              string
+	Runs            int
+	Duration        time.Duration
+	BillableMinutes int64
+}
+
+// usageKey groups on the workflow ID, since run-name can give every run of a
+// workflow its own name.
+type usageKey struct {
+	repo     string
+	workflow int64
+	os       string
+}
+
+// GetActionsUsage makes one usage request for every run, so repos restricts the
+// report to some repositories of the organization instead of all of them.
+func GetActionsUsage(org string, repos []string, since time.Time, until time.Time) ([]*UsageRow, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	if until.Before(since) {
+		return nil, fmt.Errorf("the end of the date range cannot be before its start")
+	}
+
+	repositories, err := usageRepos(client, org, repos)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make(map[usageKey]*UsageRow)
+	created := fmt.Sprintf("%s..%s", since.Format(time.DateOnly), until.Format(time.DateOnly))
+
+	for _, repository := range repositories {
+		owner := repository.GetOwner().GetLogin()
+		repo := repository.GetName()
+		msg := fmt.Errorf("could not retrieve workflow run usage for repo '%s/%s', make sure GITHUB_ACCESS_TOKEN is set and valid", owner, repo)
+
+		runs, err := paginate(math.MaxInt, false, func(page github.ListOptions) ([]*github.WorkflowRun, *github.Response, error) {
+			options := github.ListWorkflowRunsOptions{Created: created, ListOptions: page}
+			res, resp, err := client.Actions.ListRepositoryWorkflowRuns(context.Background(), owner, repo, &options)
+			if err != nil {
+				return nil, nil, msg
+			}
+			return res.WorkflowRuns, resp, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(runs) == 0 {
+			continue
+		}
+
+		workflows, err := ListRepoWorkflows(repository.GetFullName(), math.MaxInt)
+		if err != nil {
+			return nil, err
+		}
+		names := make(map[int64]string)
+		for _, workflow := range workflows.Workflows {
+			names[workflow.GetID()] = workflow.GetName()
+		}
+
+		for _, run := range runs {
+			runUsage, _, err := client.Actions.GetWorkflowRunUsageByID(context.Background(), owner, repo, run.GetID())
+			if err != nil {
+				return nil, msg
+			}
+
+			name, ok := names[run.GetWorkflowID()]
+			if !ok {
+				name = run.GetName()
+			}
+			addRunUsage(usage, repository.GetFullName(), run.GetWorkflowID(), name, runUsage)
+		}
+	}
+
+	return sortUsage(usage), nil
+}
+
+// usageRepos returns every repository of the organization, or only the given
+// ones.
+func usageRepos(client *github.Client, org string, repos []string) ([]*github.Repository, error) {
+	if len(repos) == 0 {
+		return GetOrgRepos(org, math.MaxInt, RepoFilter{})
+	}
+
+	return lookupOrgRepos(client, org, repos)
+}
+
+// addRunUsage splits a run's usage by runner OS. Runs without billable time,
+// such as those of public repositories or on self-hosted runners, are grouped
+// under "-" with the run's total duration.
+func addRunUsage(usage map[usageKey]*UsageRow, repo string, workflowID int64, workflow string, runUsage *github.WorkflowRunUsage) {
+	add := func(os string, duration time.Duration, minutes int64) {
+		key := usageKey{repo: repo, workflow: workflowID, os: os}
+		row, ok := usage[key]
+		if !ok {
+			row = &UsageRow{Repo: repo, Workflow: workflow, OS: os}
+			usage[key] = row
+		}
+		row.Runs++
+		row.Duration += duration
+		row.BillableMinutes += minutes
+	}
+
+	billable := runUsage.GetBillable()
+	if billable == nil || len(*billable) == 0 {
+		add("-", time.Duration(runUsage.GetRunDurationMS())*time.Millisecond, 0)
+		return
+	}
+
+	for os, bill := range *billable {
+		add(os, time.Duration(bill.GetTotalMS())*time.Millisecond, billableMinutes(bill))
+	}
+}
+
+// billableMinutes rounds each job up to the next minute, the way GitHub bills them.
+func billableMinutes(bill *github.WorkflowRunBill) int64 {
+	if len(bill.JobRuns) == 0 {
+		return (bill.GetTotalMS() + 59999) / 60000
+	}
+
+	var minutes int64
+	for _, job := range bill.JobRuns {
+		minutes += (job.GetDurationMS() + 59999) / 60000
+	}
+
+	return minutes
+}
+
+func sortUsage(usage map[usageKey]*UsageRow) []*UsageRow {
+	rows := make([]*UsageRow, 0, len(usage))
+	for _, row := range usage {
+		rows = append(rows, row)
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].BillableMinutes != rows[j].BillableMinutes {
+			return rows[i].BillableMinutes > rows[j].BillableMinutes
+		}
+		if rows[i].Duration != rows[j].Duration {
+			return rows[i].Duration > rows[j].Duration
+		}
+		if rows[i].Repo != rows[j].Repo {
+			return rows[i].Repo < rows[j].Repo
+		}
+		if rows[i].Workflow != rows[j].Workflow {
+			return rows[i].Workflow < rows[j].Workflow
+		}
+		return rows[i].OS < rows[j].OS
+	})
+
+	return rows
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+)
+
+func TestAddRunUsage(t *testing.T) {
+	usage := make(map[usageKey]*UsageRow)
+
+	billable := github.WorkflowRunBillMap{
+		"UBUNTU": {
+			TotalMS: github.Int64(90000),
+			JobRuns: []*github.WorkflowRunJobRun{
+				{DurationMS: github.Int64(30000)},
+				{DurationMS: github.Int64(60000)},
+			},
+		},
+		"MACOS": {TotalMS: github.Int64(61000)},
+	}
+	addRunUsage(usage, "org/api", 1, "CI", &github.WorkflowRunUsage{Billable: &billable, RunDurationMS: github.Int64(120000)})
+	addRunUsage(usage, "org/api", 1, "CI", &github.WorkflowRunUsage{Billable: &billable, RunDurationMS: github.Int64(120000)})
+	addRunUsage(usage, "org/site", 2, "Deploy", &github.WorkflowRunUsage{RunDurationMS: github.Int64(45000)})
+
+	rows := sortUsage(usage)
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 usage rows, but got %d", len(rows))
+	}
+
+	expected := []UsageRow{
+		{Repo: "org/api", Workflow: "CI", OS: "UBUNTU", Runs: 2, Duration: 180 * time.Second, BillableMinutes: 4},
+		{Repo: "org/api", Workflow: "CI", OS: "MACOS", Runs: 2, Duration: 122 * time.Second, BillableMinutes: 4},
+		{Repo: "org/site", Workflow: "Deploy", OS: "-", Runs: 1, Duration: 45 * time.Second, BillableMinutes: 0},
+	}
+	for i, row := range rows {
+		if *row != expected[i] {
+			t.Errorf("expected %+v, but got %+v", expected[i], *row)
+		}
+	}
+}
@@ -0,0 +1,380 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v55/github"
+	"gopkg.in/yaml.v3"
+)
+
+type WorkflowInput struct {
+	Name        string
+	Description string
+	Required    bool
+	Default     string
+	Type        string
+	Options     []string
+}
+
+type WorkflowDefinition struct {
+	Triggers       []string
+	Dispatchable   bool
+	DispatchInputs []*WorkflowInput
+}
+
+type WorkflowDetails struct {
+	Workflow *github.Workflow
+	LastRun  *github.WorkflowRun
+	Triggers []string
+}
+
+type workflowFile struct {
+	On yaml.Node `yaml:"on"`
+}
+
+type workflowInputSpec struct {
+	Description string      `yaml:"description"`
+	Required    bool        `yaml:"required"`
+	Default     interface{} `yaml:"default"`
+	Type        string      `yaml:"type"`
+	Options     []string    `yaml:"options"`
+}
+
+func parseWorkflowDefinition(content []byte) (*WorkflowDefinition, error) {
+	var file workflowFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("could not parse workflow file: %s", err)
+	}
+
+	definition := &WorkflowDefinition{}
+	on := &file.On
+
+	switch on.Kind {
+	case yaml.ScalarNode:
+		definition.Triggers = []string{on.Value}
+	case yaml.SequenceNode:
+		for _, trigger := range on.Content {
+			definition.Triggers = append(definition.Triggers, trigger.Value)
+		}
+	case yaml.MappingNode:
+		// mapping nodes alternate between keys and values
+		for i := 0; i+1 < len(on.Content); i += 2 {
+			trigger := on.Content[i].Value
+			definition.Triggers = append(definition.Triggers, trigger)
+
+			if trigger == "workflow_dispatch" {
+				inputs, err := parseDispatchInputs(on.Content[i+1])
+				if err != nil {
+					return nil, err
+				}
+				definition.DispatchInputs = inputs
+			}
+		}
+	}
+
+	for _, trigger := range definition.Triggers {
+		if trigger == "workflow_dispatch" {
+			definition.Dispatchable = true
+		}
+	}
+
+	return definition, nil
+}
+
+func parseDispatchInputs(dispatch *yaml.Node) ([]*WorkflowInput, error) {
+	if dispatch.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	var inputsNode *yaml.Node
+	for i := 0; i+1 < len(dispatch.Content); i += 2 {
+		if dispatch.Content[i].Value == "inputs" {
+			inputsNode = dispatch.Content[i+1]
+		}
+	}
+	if inputsNode == nil || inputsNode.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	var inputs []*WorkflowInput
+	for i := 0; i+1 < len(inputsNode.Content); i += 2 {
+		var spec workflowInputSpec
+		if err := inputsNode.Content[i+1].Decode(&spec); err != nil {
+			return nil, fmt.Errorf("could not parse workflow input '%s': %s", inputsNode.Content[i].Value, err)
+		}
+
+		input := &WorkflowInput{
+			Name:        inputsNode.Content[i].Value,
+			Description: spec.Description,
+			Required:    spec.Required,
+			Type:        spec.Type,
+			Options:     spec.Options,
+		}
+		if input.Type == "" {
+			input.Type = "string"
+		}
+		if spec.Default != nil {
+			input.Default = fmt.Sprint(spec.Default)
+		}
+
+		inputs = append(inputs, input)
+	}
+
+	return inputs, nil
+}
+
+func validateDispatchInputs(definition *WorkflowDefinition, values map[string]string) (map[string]interface{}, error) {
+	if !definition.Dispatchable {
+		return nil, fmt.Errorf("workflow does not have a workflow_dispatch trigger")
+	}
+
+	specs := make(map[string]*WorkflowInput)
+	for _, input := range definition.DispatchInputs {
+		specs[input.Name] = input
+	}
+
+	for name := range values {
+		if _, ok := specs[name]; !ok {
+			return nil, fmt.Errorf("workflow does not define an input named '%s'", name)
+		}
+	}
+
+	inputs := make(map[string]interface{})
+	for _, input := range definition.DispatchInputs {
+		value, ok := values[input.Name]
+		if !ok {
+			if input.Required && input.Default == "" {
+				return nil, fmt.Errorf("missing required input '%s'", input.Name)
+			}
+			continue
+		}
+
+		switch input.Type {
+		case "boolean":
+			if _, err := strconv.ParseBool(value); err != nil {
+				return nil, fmt.Errorf("input '%s' must be true or false, got '%s'", input.Name, value)
+			}
+		case "number":
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return nil, fmt.Errorf("input '%s' must be a number, got '%s'", input.Name, value)
+			}
+		case "choice":
+			valid := false
+			for _, option := range input.Options {
+				if option == value {
+					valid = true
+				}
+			}
+			if !valid {
+				return nil, fmt.Errorf("input '%s' must be one of %s, got '%s'", input.Name, strings.Join(input.Options, ", "), value)
+			}
+		}
+
+		// dispatch inputs are always sent as strings
+		inputs[input.Name] = value
+	}
+
+	return inputs, nil
+}
+
+func findWorkflow(workflows []*github.Workflow, selector string) (*github.Workflow, error) {
+	id, err := strconv.ParseInt(selector, 10, 64)
+	isID := err == nil
+
+	for _, workflow := range workflows {
+		if isID && workflow.GetID() == id {
+			return workflow, nil
+		}
+		if workflow.GetPath() == selector || path.Base(workflow.GetPath()) == selector {
+			return workflow, nil
+		}
+	}
+
+	var matches []*github.Workflow
+	for _, workflow := range workflows {
+		if strings.EqualFold(workflow.GetName(), selector) {
+			matches = append(matches, workflow)
+		}
+	}
+
+	if len(matches) > 1 {
+		return nil, fmt.Errorf("more than one workflow is named '%s', use its ID or file name instead", selector)
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("could not find workflow '%s'", selector)
+	}
+
+	return matches[0], nil
+}
+
+func GetWorkflow(repoPath string, selector string) (*github.Workflow, error) {
+	workflows, err := ListRepoWorkflows(repoPath, math.MaxInt)
+	if err != nil {
+		return nil, err
+	}
+
+	return findWorkflow(workflows.Workflows, selector)
+}
+
+func GetWorkflowDefinition(repoPath string, workflow *github.Workflow, ref string) (*WorkflowDefinition, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var options *github.RepositoryContentGetOptions
+	if ref != "" {
+		options = &github.RepositoryContentGetOptions{Ref: ref}
+	}
+
+	file, _, _, err := client.Repositories.GetContents(context.Background(), owner, repo, workflow.GetPath(), options)
+	if err != nil || file == nil {
+		msg := fmt.Errorf("could not retrieve workflow file '%s' in '%s', make sure the repository exists and GITHUB_ACCESS_TOKEN is set and valid", workflow.GetPath(), repoPath)
+		return nil, msg
+	}
+
+	content, err := file.GetContent()
+	if err != nil {
+		return nil, fmt.Errorf("could not decode workflow file '%s'", workflow.GetPath())
+	}
+
+	return parseWorkflowDefinition([]byte(content))
+}
+
+func DispatchWorkflow(repoPath string, selector string, ref string, values map[string]string) (*github.Workflow, string, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, "", err
+	}
+
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return nil, "", err
+	}
+
+	workflow, err := GetWorkflow(repoPath, selector)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if ref == "" {
+		repository, _, err := client.Repositories.Get(context.Background(), owner, repo)
+		if err != nil {
+			msg := fmt.Errorf("could not retrieve repository '%s', make sure the repository exists and GITHUB_ACCESS_TOKEN is set and valid", repoPath)
+			return nil, "", msg
+		}
+		ref = repository.GetDefaultBranch()
+	}
+
+	definition, err := GetWorkflowDefinition(repoPath, workflow, ref)
+	if err != nil {
+		return nil, "", err
+	}
+
+	inputs, err := validateDispatchInputs(definition, values)
+	if err != nil {
+		return nil, "", err
+	}
+
+	event := github.CreateWorkflowDispatchEventRequest{Ref: ref, Inputs: inputs}
+	_, err = client.Actions.CreateWorkflowDispatchEventByID(context.Background(), owner, repo, workflow.GetID(), event)
+	if err != nil {
+		msg := fmt.Errorf("could not dispatch workflow '%s' in '%s', make sure the ref exists and GITHUB_ACCESS_TOKEN is set and valid", workflow.GetName(), repoPath)
+		return nil, "", msg
+	}
+
+	return workflow, ref, nil
+}
+
+func ListWorkflowDetails(repoPath string, size int) ([]*WorkflowDetails, int, error) {
+	client, err := getClientInstance()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	workflows, err := ListRepoWorkflows(repoPath, size)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var details []*WorkflowDetails
+	for _, workflow := range workflows.Workflows {
+		detail := &WorkflowDetails{Workflow: workflow}
+
+		options := github.ListWorkflowRunsOptions{ListOptions: github.ListOptions{PerPage: 1}}
+		runs, _, err := client.Actions.ListWorkflowRunsByID(context.Background(), owner, repo, workflow.GetID(), &options)
+		if err != nil {
+			msg := fmt.Errorf("could not retrieve workflow runs for repo '%s', make sure the repository exists and GITHUB_ACCESS_TOKEN is set and valid", repoPath)
+			return nil, 0, msg
+		}
+		if len(runs.WorkflowRuns) > 0 {
+			detail.LastRun = runs.WorkflowRuns[0]
+		}
+
+		// workflows managed by GitHub, such as dynamic ones, have no file in the repository
+		if definition, err := GetWorkflowDefinition(repoPath, workflow, ""); err == nil {
+			detail.Triggers = definition.Triggers
+		}
+
+		details = append(details, detail)
+	}
+
+	return details, workflows.GetTotalCount(), nil
+}
+
+func PlanWorkflowStateChange(repoPath string, selector string, enable bool) ([]*Mutation, error) {
+	owner, repo, err := parseRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	workflow, err := GetWorkflow(repoPath, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	return planWorkflowStateChange(owner, repo, workflow, enable), nil
+}
+
+func planWorkflowStateChange(owner string, repo string, workflow *github.Workflow, enable bool) []*Mutation {
+	if (workflow.GetState() == "active") == enable {
+		return nil
+	}
+
+	id := workflow.GetID()
+	action := "disable"
+	apply := func(ctx context.Context, client *github.Client) error {
+		_, err := client.Actions.DisableWorkflowByID(ctx, owner, repo, id)
+		return err
+	}
+	if enable {
+		action = "enable"
+		apply = func(ctx context.Context, client *github.Client) error {
+			_, err := client.Actions.EnableWorkflowByID(ctx, owner, repo, id)
+			return err
+		}
+	}
+
+	mutation := &Mutation{
+		Method: "PUT",
+		Path:   fmt.Sprintf("/repos/%s/%s/actions/workflows/%d/%s", owner, repo, id, action),
+		apply:  apply,
+	}
+
+	return []*Mutation{mutation}
+}
@@ -0,0 +1,148 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v55/github"
+)
+
+const dispatchWorkflow = `
+name: Deploy
+on:
+  push:
+    branches: [main]
+  workflow_dispatch:
+    inputs:
+      environment:
+        description: Target environment
+        required: true
+        type: choice
+        options: [staging, production]
+      dry-run:
+        type: boolean
+        default: false
+      replicas:
+        type: number
+        default: 2
+jobs: {}
+`
+
+func TestParseWorkflowDefinitionWithDispatchInputs(t *testing.T) {
+	definition, err := parseWorkflowDefinition([]byte(dispatchWorkflow))
+	if err != nil {
+		t.Fatalf(expectedNoError, err.Error())
+	}
+
+	if !definition.Dispatchable {
+		t.Error("expected workflow to be dispatchable")
+	}
+
+	expectedTriggers := []string{"push", "workflow_dispatch"}
+	if len(definition.Triggers) != len(expectedTriggers) {
+		t.Fatalf("expected triggers %v, but got %v", expectedTriggers, definition.Triggers)
+	}
+
+	expectedInputs := []string{"environment", "dry-run", "replicas"}
+	if len(definition.DispatchInputs) != len(expectedInputs) {
+		t.Fatalf("expected %d inputs, but got %d", len(expectedInputs), len(definition.DispatchInputs))
+	}
+
+	for i, input := range definition.DispatchInputs {
+		if input.Name != expectedInputs[i] {
+			t.Errorf("expected input %d to be '%s', but got '%s'", i, expectedInputs[i], input.Name)
+		}
+	}
+
+	if definition.DispatchInputs[1].Default != "false" {
+		t.Errorf("expected dry-run to default to 'false', but got '%s'", definition.DispatchInputs[1].Default)
+	}
+}
+
+func TestParseWorkflowDefinitionWithTriggerList(t *testing.T) {
+	definition, err := parseWorkflowDefinition([]byte("on: [push, pull_request]\n"))
+	if err != nil {
+		t.Fatalf(expectedNoError, err.Error())
+	}
+
+	if definition.Dispatchable || len(definition.Triggers) != 2 {
+		t.Errorf("expected two triggers without dispatch, but got %v", definition.Triggers)
+	}
+}
+
+func TestValidateDispatchInputs(t *testing.T) {
+	definition, _ := parseWorkflowDefinition([]byte(dispatchWorkflow))
+
+	invalidInputs := map[string]map[string]string{
+		"missing required input 'environment'":                              {},
+		"workflow does not define an input named 'region'":                  {"environment": "staging", "region": "eu"},
+		"input 'environment' must be one of staging, production, got 'dev'": {"environment": "dev"},
+		"input 'dry-run' must be true or false, got 'maybe'":                {"environment": "staging", "dry-run": "maybe"},
+		"input 'replicas' must be a number, got 'many'":                     {"environment": "staging", "replicas": "many"},
+	}
+
+	for expectedError, values := range invalidInputs {
+		_, err := validateDispatchInputs(definition, values)
+		if err == nil {
+			t.Error(expectedErrorGotNil)
+		} else if err.Error() != expectedError {
+			t.Errorf(expectedDifferentError, expectedError, err.Error())
+		}
+	}
+
+	inputs, err := validateDispatchInputs(definition, map[string]string{"environment": "production", "dry-run": "true"})
+	if err != nil {
+		t.Fatalf(expectedNoError, err.Error())
+	}
+
+	if len(inputs) != 2 || inputs["environment"] != "production" {
+		t.Errorf("expected environment and dry-run inputs, but got %v", inputs)
+	}
+}
+
+func TestFindWorkflow(t *testing.T) {
+	workflows := []*github.Workflow{
+		{ID: github.Int64(1), Name: github.String("CI"), Path: github.String(".github/workflows/ci.yml")},
+		{ID: github.Int64(2), Name: github.String("Deploy"), Path: github.String(".github/workflows/deploy.yml")},
+	}
+
+	for _, selector := range []string{"2", "deploy", "deploy.yml", ".github/workflows/deploy.yml"} {
+		workflow, err := findWorkflow(workflows, selector)
+		if err != nil {
+			t.Errorf(expectedNoError, err.Error())
+		} else if workflow.GetID() != 2 {
+			t.Errorf("expected '%s' to resolve to workflow 2, but got %d", selector, workflow.GetID())
+		}
+	}
+
+	_, err := findWorkflow(workflows, "release")
+
+	expectedError := "could not find workflow 'release'"
+	if err == nil {
+		t.Error(expectedErrorGotNil)
+	} else if err.Error() != expectedError {
+		t.Errorf(expectedDifferentError, expectedError, err.Error())
+	}
+}
+
+func TestPlanWorkflowStateChange(t *testing.T) {
+	workflow := &github.Workflow{ID: github.Int64(7), State: github.String("disabled_inactivity")}
+
+	mutations := planWorkflowStateChange("owner", "repo", workflow, true)
+	if len(mutations) != 1 {
+		t.Fatalf("expected 1 mutation, but got %d", len(mutations))
+	}
+
+	expected := "PUT /repos/owner/repo/actions/workflows/7/enable"
+	if mutations[0].String() != expected {
+		t.Errorf(expectedDifferentError, expected, mutations[0].String())
+	}
+
+	if mutations := planWorkflowStateChange("owner", "repo", workflow, false); len(mutations) != 0 {
+		t.Errorf("expected no mutations for a disabled workflow, but got %d", len(mutations))
+	}
+
+	workflow.State = github.String("active")
+	if mutations := planWorkflowStateChange("owner", "repo", workflow, true); len(mutations) != 0 {
+		t.Errorf("expected no mutations for an active workflow, but got %d", len(mutations))
+	}
+}
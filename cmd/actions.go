@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/carolinafsilva/go-github-cli/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	usageOrg    string
+	usageSince  string
+	usageUntil  string
+	usageFormat string
+	usageRepos  []string
+	flakySize   int
+)
+
+var actionsCmd = &cobra.Command{
+	Use:   "actions <command> [flags]",
+	Short: "Get reports about GitHub Actions",
+	Long:  `The actions command in GG allows you to report on GitHub Actions, such as the usage across the repositories of an organization or the flaky jobs of a repository.`,
+}
+
+var actionsUsageCmd = &cobra.Command{
+	Use:   "usage [flags]",
+	Short: "Report GitHub Actions usage of an organization",
+	Long: `The usage subcommand within the actions command aggregates the duration and billable minutes of the workflow runs created in every repository of an organization, grouped by repository, workflow and runner OS. Billable minutes are rounded up per job and do not include the runner OS multipliers. The usage of each run takes its own request, so large organizations can run into the API rate limit; use --repos to report on fewer repositories.
+
+--repos: Only report on these repositories of the organization.
+--since, --until: Date range of the runs in the YYYY-MM-DD format, defaults to the last 30 days.
+--format: Output format, either table or csv.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if usageFormat != "table" && usageFormat != "csv" {
+			cmd.Printf("invalid format '%s', use table or csv\n", usageFormat)
+			return
+		}
+
+		since, until, err := parseDateRange(usageSince, usageUntil, time.Now())
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		rows, err := api.GetActionsUsage(usageOrg, usageRepos, since, until)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		if usageFormat == "csv" {
+			if err := writeUsageCSV(cmd.OutOrStdout(), rows); err != nil {
+				cmd.Println(err)
+			}
+			return
+		}
+
+		if len(rows) == 0 {
+			cmd.Println("No workflow runs found.")
+			return
+		}
+
+		magenta.Fprintf(cmd.OutOrStdout(), "%-40s %-30s %-8s %6s %12s %10s\n", "REPOSITORY", "WORKFLOW", "OS", "RUNS", "DURATION", "BILLABLE")
+		var minutes int64
+		for _, row := range rows {
+			fg.Fprintf(cmd.OutOrStdout(), "%-40s %-30s %-8s %6d %12s %9dm\n", row.Repo, row.Workflow, row.OS, row.Runs, row.Duration.Round(time.Second), row.BillableMinutes)
+			minutes += row.BillableMinutes
+		}
+		magenta.Fprintf(cmd.OutOrStdout(), "Total billable minutes: %d\n", minutes)
+	},
+}
+
+var actionsFlakyCmd = &cobra.Command{
+	Use:   "flaky <owner/repo> [flags]",
+	Short: "Find flaky jobs in a repository's workflow runs",
+	Long: `The flaky subcommand within the actions command walks a repository's recent workflow runs and every attempt of their jobs, and reports the jobs that failed and then passed for the same commit, ranked by how often that happens.
+
+--size: Number of recent completed runs to inspect.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		repoPath := args[0]
+
+		jobs, err := api.GetFlakyJobs(repoPath, flakySize)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		if len(jobs) == 0 {
+			cmd.Println("No flaky jobs found.")
+			return
+		}
+
+		for _, job := range jobs {
+			magenta.Fprintf(cmd.OutOrStdout(), "%5.1f%% ", job.Rate()*100)
+			fg.Fprintf(cmd.OutOrStdout(), "%s / %s  (%d of %d commits)\n", job.Workflow, job.Job, job.Flakes, job.Commits)
+			for _, url := range job.URLs {
+				fg.Fprintf(cmd.OutOrStdout(), "       %s\n", url)
+			}
+		}
+	},
+}
+
+// parseDateRange returns the start of since and the end of until, defaulting
+// to the 30 days before now.
+func parseDateRange(since string, until string, now time.Time) (time.Time, time.Time, error) {
+	end := now
+	if until != "" {
+		date, err := time.Parse(time.DateOnly, until)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid date '%s', use the YYYY-MM-DD format", until)
+		}
+		end = date.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	start := end.AddDate(0, 0, -30)
+	if since != "" {
+		date, err := time.Parse(time.DateOnly, since)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid date '%s', use the YYYY-MM-DD format", since)
+		}
+		start = date
+	}
+
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("--since cannot be after --until")
+	}
+
+	return start, end, nil
+}
+
+func writeUsageCSV(out io.Writer, rows []*api.UsageRow) error {
+	writer := csv.NewWriter(out)
+	writer.Write([]string{"repository", "workflow", "os", "runs", "duration_seconds", "billable_minutes"})
+
+	for _, row := range rows {
+		writer.Write([]string{
+			row.Repo,
+			row.Workflow,
+			row.OS,
+			strconv.Itoa(row.Runs),
+			strconv.FormatInt(int64(row.Duration.Seconds()), 10),
+			strconv.FormatInt(row.BillableMinutes, 10),
+		})
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func init() {
+	rootCmd.AddCommand(actionsCmd)
+	actionsCmd.AddCommand(actionsUsageCmd)
+	actionsCmd.AddCommand(actionsFlakyCmd)
+
+	actionsUsageCmd.Flags().StringVarP(&usageOrg, "org", "o", "", "Organization to report on")
+	actionsUsageCmd.Flags().StringVar(&usageSince, "since", "", "Only include runs created on or after this date")
+	actionsUsageCmd.Flags().StringVar(&usageUntil, "until", "", "Only include runs created on or before this date")
+	actionsUsageCmd.Flags().StringSliceVar(&usageRepos, "repos", nil, "Repositories of the organization to report on")
+	actionsUsageCmd.Flags().StringVarP(&usageFormat, "format", "f", "table", "Output format, table or csv")
+	actionsUsageCmd.MarkFlagRequired("org")
+
+	actionsFlakyCmd.Flags().IntVarP(&flakySize, "size", "s", 100, "Number of recent runs to inspect")
+}
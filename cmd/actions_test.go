@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/carolinafsilva/go-github-cli/api"
+)
+
+func TestActionsUsageCmdWithInvalidFormat(t *testing.T) {
+	cmd := rootCmd
+
+	var output bytes.Buffer
+	cmd.SetOut(&output)
+
+	cmd.SetArgs([]string{"actions", "usage", "--org", "carolinafsilva", "--format", "json"})
+
+	err := cmd.Execute()
+	if err != nil {
+		t.Errorf(expectedNoError, err)
+	}
+
+	expectedMsg := "invalid format 'json', use table or csv\n"
+	if output.String() != expectedMsg {
+		t.Errorf(expectedDifferentError, expectedMsg, output.String())
+	}
+
+	t.Cleanup(func() {
+		cmd.SetOut(nil)
+		usageOrg = ""
+		usageFormat = "table"
+	})
+}
+
+func TestActionsFlakyCmdWithNoArgs(t *testing.T) {
+	cmd := rootCmd
+
+	cmd.SetArgs([]string{"actions", "flaky"})
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal(expectedErrorGotNil)
+	}
+
+	expectedErr := "accepts 1 arg(s), received 0"
+	if err.Error() != expectedErr {
+		t.Errorf(expectedDifferentError, expectedErr, err.Error())
+	}
+}
+
+func TestParseDateRange(t *testing.T) {
+	now := time.Date(2023, 10, 31, 15, 0, 0, 0, time.UTC)
+
+	since, until, err := parseDateRange("", "", now)
+	if err != nil {
+		t.Fatalf(expectedNoError, err)
+	}
+	if !until.Equal(now) || !since.Equal(now.AddDate(0, 0, -30)) {
+		t.Errorf(expectedDifferentError, "the last 30 days", since.String()+" - "+until.String())
+	}
+
+	since, until, err = parseDateRange("2023-09-01", "2023-09-30", now)
+	if err != nil {
+		t.Fatalf(expectedNoError, err)
+	}
+	if since.Format(time.DateTime) != "2023-09-01 00:00:00" || until.Format(time.DateTime) != "2023-09-30 23:59:59" {
+		t.Errorf(expectedDifferentError, "2023-09-01 00:00:00 - 2023-09-30 23:59:59", since.String()+" - "+until.String())
+	}
+
+	_, _, err = parseDateRange("2023-10-01", "2023-09-01", now)
+	if err == nil {
+		t.Error(expectedErrorGotNil)
+	}
+
+	_, _, err = parseDateRange("01/09/2023", "", now)
+
+	expectedErr := "invalid date '01/09/2023', use the YYYY-MM-DD format"
+	if err == nil {
+		t.Error(expectedErrorGotNil)
+	} else if err.Error() != expectedErr {
+		t.Errorf(expectedDifferentError, expectedErr, err.Error())
+	}
+}
+
+func TestWriteUsageCSV(t *testing.T) {
+	var output bytes.Buffer
+
+	rows := []*api.UsageRow{{Repo: "org/api", Workflow: "CI, nightly", OS: "UBUNTU", Runs: 3, Duration: 150 * time.Second, BillableMinutes: 4}}
+	if err := writeUsageCSV(&output, rows); err != nil {
+		t.Fatalf(expectedNoError, err)
+	}
+
+	expected := "repository,workflow,os,runs,duration_seconds,billable_minutes\norg/api,\"CI, nightly\",UBUNTU,3,150,4\n"
+	if output.String() != expected {
+		t.Errorf(expectedDifferentError, expected, output.String())
+	}
+}
@@ -0,0 +1,264 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/carolinafsilva/go-github-cli/api"
+	"github.com/google/go-github/v55/github"
+	"github.com/spf13/cobra"
+)
+
+var (
+	deleteMerged    bool
+	deleteOlderThan string
+	deleteBranchYes bool
+)
+
+var branchCmd = &cobra.Command{
+	Use:   "branch <command> [flags]",
+	Short: "Manage the branches of a repository",
+	Long: `The branch command in GG allows you to list, delete, rename and compare the branches of a repository.
+
+--repo: Repository in the <owner/repo> format, defaults to the origin remote of the current directory.`,
+}
+
+var branchListCmd = &cobra.Command{
+	Use:   "list [flags]",
+	Short: "List branches",
+	Long:  `The list subcommand within the branch command lists the branches of a repository with their last commit, protection and how many commits they are ahead of and behind the default branch.`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		repoPath, err := resolveRepoPath()
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		branches, err := api.ListBranches(repoPath)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		fg.Fprint(cmd.OutOrStdout(), renderBranches(branches, time.Now()))
+	},
+}
+
+var branchDeleteCmd = &cobra.Command{
+	Use:   "delete [<branch>...] [flags]",
+	Short: "Delete branches",
+	Long: `The delete subcommand within the branch command deletes the given branches, or the branches selected with --merged and --older-than after asking to type the repository name to confirm. The default branch and protected branches are never selected.
+
+--merged: Select the branches without commits missing from the default branch.
+--older-than: Select the branches last committed to before this age, such as 90d, 2w or 12h.
+--yes: Skip the confirmation prompt.
+--dry-run: Show the API calls that would be made without performing them.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		filtered := deleteMerged || deleteOlderThan != ""
+		if filtered == (len(args) > 0) {
+			cmd.Println("give either branch names or --merged and --older-than")
+			return
+		}
+
+		olderThan, err := parseOlderThan(deleteOlderThan)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		repoPath, err := resolveRepoPath()
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		names := args
+		if filtered {
+			branches, err := api.ListBranches(repoPath)
+			if err != nil {
+				cmd.Println(err)
+				return
+			}
+
+			selected := api.FilterBranches(branches, deleteMerged, olderThan, time.Now())
+			if len(selected) == 0 {
+				cmd.Println("No branches matched.")
+				return
+			}
+
+			names = nil
+			fg.Fprint(cmd.OutOrStdout(), renderBranches(selected, time.Now()))
+			for _, branch := range selected {
+				names = append(names, branch.Name)
+			}
+
+			if !dryRun && !deleteBranchYes && !confirmRepoName(cmd, repoPath) {
+				cmd.Println("The repository name did not match, no branches were deleted.")
+				return
+			}
+		}
+
+		mutations, err := api.PlanBranchDelete(repoPath, names)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		if applyMutations(cmd, mutations) {
+			cmd.Printf("Deleted %d branches from %s\n", len(names), repoPath)
+		}
+	},
+}
+
+var branchRenameCmd = &cobra.Command{
+	Use:   "rename <branch> <new-name> [flags]",
+	Short: "Rename a branch",
+	Long: `The rename subcommand within the branch command renames a branch. GitHub retargets the open pull requests and the branch protection to the new name.
+
+--dry-run: Show the API calls that would be made without performing them.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		repoPath, err := resolveRepoPath()
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		mutations, err := api.PlanBranchRename(repoPath, args[0], args[1])
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		if applyMutations(cmd, mutations) {
+			cmd.Printf("Renamed %s to %s in %s\n", args[0], args[1], repoPath)
+		}
+	},
+}
+
+var branchCompareCmd = &cobra.Command{
+	Use:   "compare <base> <head> [flags]",
+	Short: "Compare two refs",
+	Long:  `The compare subcommand within the branch command shows the commits and the changed files between two branches, tags or commits.`,
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		repoPath, err := resolveRepoPath()
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		comparison, err := api.CompareRefs(repoPath, args[0], args[1])
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		fg.Fprint(cmd.OutOrStdout(), renderComparison(args[0], args[1], comparison))
+	},
+}
+
+func renderBranches(branches []*api.BranchInfo, now time.Time) string {
+	var table strings.Builder
+	row := "%-35s %-8s %-18s %-8s %-12s %-9s %s\n"
+
+	table.WriteString(magenta.Sprintf(row, "NAME", "COMMIT", "AUTHOR", "UPDATED", "AHEAD/BEHIND", "PROTECTED", "MESSAGE"))
+	for _, branch := range branches {
+		name := branch.Name
+		if branch.Default {
+			name += " (default)"
+		}
+
+		sha := branch.SHA
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+
+		aheadBehind := "-"
+		if !branch.Default {
+			aheadBehind = fmt.Sprintf("+%d -%d", branch.Ahead, branch.Behind)
+		}
+
+		protected := "no"
+		if branch.Protected {
+			protected = "yes"
+		}
+
+		table.WriteString(fg.Sprintf(row, name, sha, branch.Author, formatAge(now.Sub(branch.CommittedAt)), aheadBehind, protected, branch.Message))
+	}
+
+	return table.String()
+}
+
+func renderComparison(base string, head string, comparison *github.CommitsComparison) string {
+	var out strings.Builder
+
+	out.WriteString(magenta.Sprintf("%s...%s", base, head))
+	out.WriteString(fmt.Sprintf(" %s, %d ahead, %d behind\n", comparison.GetStatus(), comparison.GetAheadBy(), comparison.GetBehindBy()))
+
+	if len(comparison.Commits) < comparison.GetTotalCommits() {
+		out.WriteString(fmt.Sprintf("\nCommits (showing %d of %d):\n", len(comparison.Commits), comparison.GetTotalCommits()))
+	} else {
+		out.WriteString(fmt.Sprintf("\nCommits (%d):\n", len(comparison.Commits)))
+	}
+	for _, commit := range comparison.Commits {
+		message, _, _ := strings.Cut(commit.GetCommit().GetMessage(), "\n")
+		author := commit.GetAuthor().GetLogin()
+		if author == "" {
+			author = commit.GetCommit().GetAuthor().GetName()
+		}
+		out.WriteString(fmt.Sprintf("  %.7s %s (%s)\n", commit.GetSHA(), message, author))
+	}
+
+	out.WriteString(fmt.Sprintf("\nFiles (%d):\n", len(comparison.Files)))
+	for _, file := range comparison.Files {
+		out.WriteString(fmt.Sprintf("  %-9s %s +%d -%d\n", file.GetStatus(), file.GetFilename(), file.GetAdditions(), file.GetDeletions()))
+	}
+
+	return out.String()
+}
+
+// parseOlderThan accepts days and weeks on top of the units of
+// time.ParseDuration.
+func parseOlderThan(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	msg := fmt.Errorf("invalid age '%s', use a number of days, weeks or hours such as 90d, 2w or 12h", value)
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if number, ok := strings.CutSuffix(value, suffix); ok {
+			count, err := strconv.Atoi(number)
+			if err != nil || count <= 0 {
+				return 0, msg
+			}
+			return time.Duration(count) * unit, nil
+		}
+	}
+
+	age, err := time.ParseDuration(value)
+	if err != nil || age <= 0 {
+		return 0, msg
+	}
+
+	return age, nil
+}
+
+func init() {
+	rootCmd.AddCommand(branchCmd)
+	branchCmd.AddCommand(branchListCmd)
+	branchCmd.AddCommand(branchDeleteCmd)
+	branchCmd.AddCommand(branchRenameCmd)
+	branchCmd.AddCommand(branchCompareCmd)
+
+	branchCmd.PersistentFlags().StringVarP(&repoFlag, "repo", "R", "", "Repository in the <owner/repo> format")
+
+	branchDeleteCmd.Flags().BoolVar(&deleteMerged, "merged", false, "Delete the branches merged into the default branch")
+	branchDeleteCmd.Flags().StringVar(&deleteOlderThan, "older-than", "", "Delete the branches last committed to before this age, such as 90d")
+	branchDeleteCmd.Flags().BoolVarP(&deleteBranchYes, "yes", "y", false, "Delete without asking for confirmation")
+	branchDeleteCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the API calls without performing them")
+	branchRenameCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the API calls without performing them")
+}
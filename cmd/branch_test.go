@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/carolinafsilva/go-github-cli/api"
+	"github.com/google/go-github/v55/github"
+)
+
+func TestBranchCmdPrintHelpMenu(t *testing.T) {
+	cmd := rootCmd
+
+	var output bytes.Buffer
+	cmd.SetOut(&output)
+
+	args := []string{"--help", "-h", "help", "", "invalidcmd"}
+
+	expectedOutput := "The branch command in GG allows you to list, delete, rename and compare the branches of a repository.\n\n--repo: Repository in the <owner/repo> format, defaults to the origin remote of the current directory.\n\nUsage:\n  gg branch [command]\n\nAvailable Commands:\n  compare     Compare two refs\n  delete      Delete branches\n  list        List branches\n  rename      Rename a branch\n\nFlags:\n  -h, --help          help for branch\n  -R, --repo string   Repository in the <owner/repo> format\n\nUse \"gg branch [command] --help\" for more information about a command.\n"
+
+	for _, arg := range args {
+		cmd.SetArgs([]string{"branch", arg})
+		err := cmd.Execute()
+		if err != nil {
+			t.Errorf(expectedNoError, err)
+		}
+
+		if output.String() != expectedOutput {
+			t.Errorf(expectedDifferentError, expectedOutput, output.String())
+		}
+
+		output.Reset()
+	}
+
+	t.Cleanup(func() {
+		cmd.SetOut(nil)
+	})
+}
+
+func TestBranchDeleteCmdWithNamesAndFilters(t *testing.T) {
+	cmd := rootCmd
+
+	var output bytes.Buffer
+	cmd.SetOut(&output)
+
+	cmd.SetArgs([]string{"branch", "delete", "feature", "--merged", "--repo", "acme/api"})
+	err := cmd.Execute()
+	if err != nil {
+		t.Errorf(expectedNoError, err)
+	}
+
+	expectedMsg := "give either branch names or --merged and --older-than\n"
+	if output.String() != expectedMsg {
+		t.Errorf(expectedDifferentError, expectedMsg, output.String())
+	}
+
+	t.Cleanup(func() {
+		cmd.SetOut(nil)
+		repoFlag = ""
+		deleteMerged = false
+	})
+}
+
+func TestBranchDeleteCmdWithDryRun(t *testing.T) {
+	cmd := rootCmd
+
+	var output bytes.Buffer
+	cmd.SetOut(&output)
+
+	cmd.SetArgs([]string{"branch", "delete", "feature/login", "--repo", "acme/api", "--dry-run"})
+	err := cmd.Execute()
+	if err != nil {
+		t.Errorf(expectedNoError, err)
+	}
+
+	expectedMsg := "DELETE /repos/acme/api/git/refs/heads/feature/login\n"
+	if output.String() != expectedMsg {
+		t.Errorf(expectedDifferentError, expectedMsg, output.String())
+	}
+
+	t.Cleanup(func() {
+		cmd.SetOut(nil)
+		repoFlag = ""
+		dryRun = false
+	})
+}
+
+func TestParseOlderThan(t *testing.T) {
+	ages := map[string]time.Duration{"": 0, "90d": 90 * 24 * time.Hour, "2w": 14 * 24 * time.Hour, "12h": 12 * time.Hour}
+
+	for value, expected := range ages {
+		age, err := parseOlderThan(value)
+		if err != nil {
+			t.Errorf(expectedNoError, err)
+		}
+
+		if age != expected {
+			t.Errorf(expectedDifferentError, expected, age)
+		}
+	}
+
+	_, err := parseOlderThan("3 months")
+	if err == nil {
+		t.Fatal(expectedErrorGotNil)
+	}
+
+	expectedErr := "invalid age '3 months', use a number of days, weeks or hours such as 90d, 2w or 12h"
+	if err.Error() != expectedErr {
+		t.Errorf(expectedDifferentError, expectedErr, err.Error())
+	}
+}
+
+func TestRenderBranches(t *testing.T) {
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	branches := []*api.BranchInfo{
+		{Name: "main", Default: true, Protected: true, SHA: "0123456789", Author: "octocat", Message: "Release", CommittedAt: now.Add(-time.Hour)},
+		{Name: "feature", SHA: "abcdef0123", Author: "hubot", Message: "Add login", CommittedAt: now.Add(-3 * 24 * time.Hour), Ahead: 2, Behind: 5},
+	}
+
+	table := renderBranches(branches, now)
+
+	row := "%-35s %-8s %-18s %-8s %-12s %-9s %s\n"
+	for _, expected := range []string{
+		fg.Sprintf(row, "main (default)", "0123456", "octocat", "1h", "-", "yes", "Release"),
+		fg.Sprintf(row, "feature", "abcdef0", "hubot", "3d", "+2 -5", "no", "Add login"),
+	} {
+		if !strings.Contains(table, expected) {
+			t.Errorf(expectedDifferentError, expected, table)
+		}
+	}
+}
+
+func TestRenderComparison(t *testing.T) {
+	comparison := &github.CommitsComparison{
+		Status:       github.String("ahead"),
+		AheadBy:      github.Int(1),
+		BehindBy:     github.Int(0),
+		TotalCommits: github.Int(1),
+		Commits: []*github.RepositoryCommit{{
+			SHA:    github.String("abcdef0123"),
+			Author: &github.User{Login: github.String("octocat")},
+			Commit: &github.Commit{Message: github.String("Add login\n\nWith tests")},
+		}},
+		Files: []*github.CommitFile{{Filename: github.String("login.go"), Status: github.String("added"), Additions: github.Int(40)}},
+	}
+
+	output := renderComparison("main", "feature", comparison)
+
+	expected := "main...feature ahead, 1 ahead, 0 behind\n\nCommits (1):\n  abcdef0 Add login (octocat)\n\nFiles (1):\n  added     login.go +40 -0\n"
+	if output != expected {
+		t.Errorf(expectedDifferentError, expected, output)
+	}
+}
+
+func TestRenderComparisonWithMissingCommits(t *testing.T) {
+	comparison := &github.CommitsComparison{
+		TotalCommits: github.Int(300),
+		Commits:      []*github.RepositoryCommit{{SHA: github.String("abcdef0123")}},
+	}
+
+	output := renderComparison("main", "feature", comparison)
+
+	expected := "Commits (showing 1 of 300):"
+	if !strings.Contains(output, expected) {
+		t.Errorf(expectedDifferentError, expected, output)
+	}
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+)
+
+func openInBrowser(url string) error {
+	var command *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		command = exec.Command("open", url)
+	case "windows":
+		command = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		command = exec.Command("xdg-open", url)
+	}
+
+	if err := command.Start(); err != nil {
+		return fmt.Errorf("could not open '%s' in the browser", url)
+	}
+
+	return nil
+}
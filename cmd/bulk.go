@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/carolinafsilva/go-github-cli/api"
+	"github.com/fatih/color"
+	"github.com/google/go-github/v55/github"
+	"github.com/spf13/cobra"
+)
+
+var (
+	bulkFilter      api.RepoFilter
+	bulkOrg         string
+	bulkTeam        string
+	bulkUser        string
+	bulkConcurrency int
+)
+
+var errBulkFailed = errors.New("some repositories could not be updated")
+
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+type bulkResult struct {
+	repo      string
+	mutations []*api.Mutation
+	err       error
+}
+
+var repoBulkCmd = &cobra.Command{
+	Use:   "bulk <archive|unarchive|edit> [flags]",
+	Short: "Apply a change to many repositories",
+	Long: `The bulk subcommand within the repo command archives, unarchives or edits many repositories at once, and reports the outcome for each of them. The command exits with a non-zero status when any repository fails.
+
+The repositories are the ones of --org, --team or --user, narrowed down with the same filters as the list subcommand. Without any of them, the repositories are read from stdin, one <owner/repo> per line, so the output of gg repo list can be piped in.
+
+The edit action takes the same flags as the edit subcommand.
+
+--concurrency: Number of repositories updated at the same time.
+--dry-run: Show the API calls for each repository without performing them.`,
+	Args:         cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	ValidArgs:    []string{"archive", "unarchive", "edit"},
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if bulkConcurrency < 1 {
+			return fmt.Errorf("--concurrency must be at least 1")
+		}
+
+		plan := bulkPlan(cmd, args[0])
+
+		repos, err := bulkRepos(cmd)
+		if err != nil {
+			return err
+		}
+
+		if len(repos) == 0 {
+			cmd.Println("No repositories matched.")
+			return nil
+		}
+
+		results := runBulk(repos, bulkConcurrency, func(repoPath string) bulkResult {
+			mutations, err := plan(repoPath)
+			if err == nil && !dryRun && len(mutations) > 0 {
+				err = api.ApplyMutations(mutations)
+			}
+			return bulkResult{repo: repoPath, mutations: mutations, err: err}
+		})
+
+		if printBulkReport(cmd, results) > 0 {
+			return errBulkFailed
+		}
+
+		return nil
+	},
+}
+
+func bulkPlan(cmd *cobra.Command, action string) func(repoPath string) ([]*api.Mutation, error) {
+	switch action {
+	case "archive":
+		return func(repoPath string) ([]*api.Mutation, error) {
+			return api.PlanRepoArchive(repoPath, true)
+		}
+	case "unarchive":
+		return func(repoPath string) ([]*api.Mutation, error) {
+			return api.PlanRepoArchive(repoPath, false)
+		}
+	}
+
+	edit := repoEditFromFlags(cmd)
+	return func(repoPath string) ([]*api.Mutation, error) {
+		return api.PlanRepoEdit(repoPath, edit)
+	}
+}
+
+func bulkRepos(cmd *cobra.Command) ([]string, error) {
+	var repos []*github.Repository
+	var err error
+
+	switch {
+	case bulkOrg != "":
+		repos, err = api.GetOrgRepos(bulkOrg, math.MaxInt, bulkFilter)
+	case bulkTeam != "":
+		repos, err = api.GetTeamRepos(bulkTeam, math.MaxInt, bulkFilter)
+	case bulkUser != "":
+		repos, err = api.GetOwnedRepos(bulkUser, math.MaxInt, bulkFilter)
+	default:
+		return readRepoPaths(cmd.InOrStdin())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, repo := range repos {
+		paths = append(paths, repo.GetFullName())
+	}
+
+	return paths, nil
+}
+
+// readRepoPaths takes the first word of each line that looks like
+// <owner/repo>, which skips the titles and headers of gg repo list.
+func readRepoPaths(reader io.Reader) ([]string, error) {
+	var paths []string
+	seen := make(map[string]bool)
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		fields := strings.Fields(ansiEscape.ReplaceAllString(scanner.Text(), ""))
+		if len(fields) == 0 || strings.Count(fields[0], "/") != 1 || strings.HasPrefix(fields[0], "/") || strings.HasSuffix(fields[0], "/") {
+			continue
+		}
+		if !seen[fields[0]] {
+			seen[fields[0]] = true
+			paths = append(paths, fields[0])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read the repositories from stdin")
+	}
+
+	return paths, nil
+}
+
+// runBulk runs task for every repository with at most concurrency of them at
+// a time, and returns the results in the order of repos.
+func runBulk(repos []string, concurrency int, task func(repoPath string) bulkResult) []bulkResult {
+	results := make([]bulkResult, len(repos))
+	slots := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i, repo := range repos {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int, repo string) {
+			defer wg.Done()
+			results[i] = task(repo)
+			<-slots
+		}(i, repo)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// printBulkReport prints the outcome for each repository and returns how many
+// failed.
+func printBulkReport(cmd *cobra.Command, results []bulkResult) int {
+	green := color.New(color.FgGreen)
+	red := color.New(color.FgRed)
+	updated, unchanged, failed := 0, 0, 0
+
+	for _, result := range results {
+		switch {
+		case result.err != nil:
+			failed++
+			red.Fprintf(cmd.OutOrStdout(), "✗ %s: %v\n", result.repo, result.err)
+		case len(result.mutations) == 0:
+			unchanged++
+			fg.Fprintf(cmd.OutOrStdout(), "- %s: already up to date\n", result.repo)
+		default:
+			updated++
+			green.Fprintf(cmd.OutOrStdout(), "✓ %s\n", result.repo)
+			if dryRun {
+				for _, mutation := range result.mutations {
+					fg.Fprintf(cmd.OutOrStdout(), "    %s\n", mutation)
+				}
+			}
+		}
+	}
+
+	action := "updated"
+	if dryRun {
+		action = "to update"
+	}
+	fg.Fprintf(cmd.OutOrStdout(), "\n%d %s, %d unchanged, %d failed\n", updated, action, unchanged, failed)
+
+	return failed
+}
+
+func init() {
+	repoCmd.AddCommand(repoBulkCmd)
+
+	repoBulkCmd.Flags().StringVar(&bulkOrg, "org", "", "Use the repositories of an organization")
+	repoBulkCmd.Flags().StringVar(&bulkTeam, "team", "", "Use the repositories of a team, in the <org/team> format")
+	repoBulkCmd.Flags().StringVar(&bulkUser, "user", "", "Use the repositories owned by a user")
+	repoBulkCmd.MarkFlagsMutuallyExclusive("org", "team", "user")
+	repoBulkCmd.Flags().StringVar(&bulkFilter.Language, "language", "", "Filter by primary language")
+	repoBulkCmd.Flags().StringVar(&bulkFilter.Topic, "topic", "", "Filter by topic")
+	repoBulkCmd.Flags().BoolVar(&bulkFilter.NoArchived, "no-archived", false, "Omit archived repositories")
+	repoBulkCmd.Flags().BoolVar(&bulkFilter.Source, "source", false, "Use only non-forks")
+	repoBulkCmd.Flags().BoolVar(&bulkFilter.Fork, "fork", false, "Use only forks")
+	repoBulkCmd.MarkFlagsMutuallyExclusive("source", "fork")
+	repoBulkCmd.Flags().IntVarP(&bulkConcurrency, "concurrency", "c", 4, "Number of repositories updated at the same time")
+	repoBulkCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the API calls without performing them")
+	addRepoEditFlags(repoBulkCmd)
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/carolinafsilva/go-github-cli/api"
+)
+
+func TestRepoBulkCmdWithInvalidAction(t *testing.T) {
+	cmd := rootCmd
+
+	cmd.SetArgs([]string{"repo", "bulk", "delete"})
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal(expectedErrorGotNil)
+	}
+
+	expectedErr := `invalid argument "delete" for "gg repo bulk"`
+	if err.Error() != expectedErr {
+		t.Errorf(expectedDifferentError, expectedErr, err.Error())
+	}
+}
+
+func TestRepoBulkCmdWithInvalidConcurrency(t *testing.T) {
+	cmd := rootCmd
+
+	cmd.SetArgs([]string{"repo", "bulk", "archive", "--concurrency", "0"})
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal(expectedErrorGotNil)
+	}
+
+	expectedErr := "--concurrency must be at least 1"
+	if err.Error() != expectedErr {
+		t.Errorf(expectedDifferentError, expectedErr, err.Error())
+	}
+
+	t.Cleanup(func() {
+		bulkConcurrency = 4
+	})
+}
+
+func TestReadRepoPaths(t *testing.T) {
+	input := "\x1b[35;4mOwned Repositories:\x1b[0m\n\x1b[35mNAME       VISIBILITY\x1b[0m\nacme/api   public\nacme/web   private\n\nacme/api\nnot-a-repo\n/acme\n"
+
+	paths, err := readRepoPaths(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf(expectedNoError, err)
+	}
+
+	expected := []string{"acme/api", "acme/web"}
+	if strings.Join(paths, ",") != strings.Join(expected, ",") {
+		t.Errorf(expectedDifferentError, expected, paths)
+	}
+}
+
+func TestRunBulk(t *testing.T) {
+	repos := []string{"acme/a", "acme/b", "acme/c", "acme/d", "acme/e"}
+
+	var mutex sync.Mutex
+	running, maxRunning := 0, 0
+	results := runBulk(repos, 2, func(repoPath string) bulkResult {
+		mutex.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mutex.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mutex.Lock()
+		running--
+		mutex.Unlock()
+		return bulkResult{repo: repoPath}
+	})
+
+	if maxRunning > 2 {
+		t.Errorf("expected at most 2 repositories at a time, got %d", maxRunning)
+	}
+
+	for i, result := range results {
+		if result.repo != repos[i] {
+			t.Errorf(expectedDifferentError, repos[i], result.repo)
+		}
+	}
+}
+
+func TestPrintBulkReport(t *testing.T) {
+	var output bytes.Buffer
+	repoBulkCmd.SetOut(&output)
+
+	results := []bulkResult{
+		{repo: "acme/api", mutations: []*api.Mutation{{Method: "PATCH", Path: "/repos/acme/api"}}},
+		{repo: "acme/web"},
+		{repo: "acme/cli", err: errors.New("not found")},
+	}
+
+	failed := printBulkReport(repoBulkCmd, results)
+	if failed != 1 {
+		t.Errorf(expectedDifferentError, 1, failed)
+	}
+
+	expected := "✓ acme/api\n- acme/web: already up to date\n✗ acme/cli: not found\n\n1 updated, 1 unchanged, 1 failed\n"
+	if output.String() != expected {
+		t.Errorf(expectedDifferentError, expected, output.String())
+	}
+
+	t.Cleanup(func() {
+		repoBulkCmd.SetOut(nil)
+	})
+}
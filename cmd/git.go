@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/spf13/cobra"
+)
+
+var repoFlag string
+
+func parseRemoteURL(remoteURL string) (string, error) {
+	path := remoteURL
+
+	switch {
+	case strings.HasPrefix(path, "git@github.com:"):
+		path = strings.TrimPrefix(path, "git@github.com:")
+	case strings.HasPrefix(path, "https://github.com/"):
+		path = strings.TrimPrefix(path, "https://github.com/")
+	case strings.HasPrefix(path, "ssh://git@github.com/"):
+		path = strings.TrimPrefix(path, "ssh://git@github.com/")
+	default:
+		return "", fmt.Errorf("remote '%s' is not a GitHub repository", remoteURL)
+	}
+
+	path = strings.TrimSuffix(strings.TrimSuffix(path, "/"), ".git")
+	if len(strings.Split(path, "/")) != 2 {
+		return "", fmt.Errorf("remote '%s' is not a GitHub repository", remoteURL)
+	}
+
+	return path, nil
+}
+
+func resolveRepoPath() (string, error) {
+	if repoFlag != "" {
+		return repoFlag, nil
+	}
+
+	out, err := exec.Command("git", "remote", "get-url", "origin").Output()
+	if err != nil {
+		return "", fmt.Errorf("could not determine the repository, use --repo <owner/repo> or run gg inside a clone of a GitHub repository")
+	}
+
+	return parseRemoteURL(strings.TrimSpace(string(out)))
+}
+
+// gitProtocol returns the protocol to clone with, https unless GG_GIT_PROTOCOL
+// is set to ssh.
+func gitProtocol() (string, error) {
+	godotenv.Load()
+	protocol := strings.ToLower(os.Getenv("GG_GIT_PROTOCOL"))
+	switch protocol {
+	case "":
+		return "https", nil
+	case "https", "ssh":
+		return protocol, nil
+	}
+
+	return "", fmt.Errorf("invalid git protocol '%s', GG_GIT_PROTOCOL must be 'https' or 'ssh'", protocol)
+}
+
+func cloneURL(repoPath string, protocol string) string {
+	if protocol == "ssh" {
+		return fmt.Sprintf("git@github.com:%s.git", repoPath)
+	}
+
+	return fmt.Sprintf("https://github.com/%s.git", repoPath)
+}
+
+func runGit(cmd *cobra.Command, args ...string) error {
+	git := exec.Command("git", args...)
+	git.Stdin = os.Stdin
+	git.Stdout = cmd.OutOrStdout()
+	git.Stderr = cmd.ErrOrStderr()
+
+	if err := git.Run(); err != nil {
+		return fmt.Errorf("git %s failed: %v", args[0], err)
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestParseRemoteURLWithValidRemotes(t *testing.T) {
+	remotes := []string{
+		"git@github.com:owner/repo.git",
+		"git@github.com:owner/repo",
+		"https://github.com/owner/repo.git",
+		"https://github.com/owner/repo/",
+		"ssh://git@github.com/owner/repo.git",
+	}
+
+	for _, remote := range remotes {
+		repoPath, err := parseRemoteURL(remote)
+		if err != nil {
+			t.Errorf(expectedNoError, err)
+		}
+
+		if repoPath != "owner/repo" {
+			t.Errorf(expectedDifferentError, "owner/repo", repoPath)
+		}
+	}
+}
+
+func TestParseRemoteURLWithInvalidRemote(t *testing.T) {
+	_, err := parseRemoteURL("https://gitlab.com/owner/repo.git")
+	if err == nil {
+		t.Fatal(expectedErrorGotNil)
+	}
+
+	expectedErr := "remote 'https://gitlab.com/owner/repo.git' is not a GitHub repository"
+	if err.Error() != expectedErr {
+		t.Errorf(expectedDifferentError, expectedErr, err.Error())
+	}
+}
+
+func TestResolveRepoPathWithRepoFlag(t *testing.T) {
+	repoFlag = "owner/repo"
+
+	repoPath, err := resolveRepoPath()
+	if err != nil {
+		t.Errorf(expectedNoError, err)
+	}
+
+	if repoPath != "owner/repo" {
+		t.Errorf(expectedDifferentError, "owner/repo", repoPath)
+	}
+
+	t.Cleanup(func() {
+		repoFlag = ""
+	})
+}
+
+func TestGitProtocol(t *testing.T) {
+	protocols := map[string]string{"": "https", "SSH": "ssh", "https": "https"}
+
+	for value, expected := range protocols {
+		t.Setenv("GG_GIT_PROTOCOL", value)
+
+		protocol, err := gitProtocol()
+		if err != nil {
+			t.Errorf(expectedNoError, err)
+		}
+
+		if protocol != expected {
+			t.Errorf(expectedDifferentError, expected, protocol)
+		}
+	}
+
+	t.Setenv("GG_GIT_PROTOCOL", "git")
+	_, err := gitProtocol()
+	if err == nil {
+		t.Fatal(expectedErrorGotNil)
+	}
+
+	expectedErr := "invalid git protocol 'git', GG_GIT_PROTOCOL must be 'https' or 'ssh'"
+	if err.Error() != expectedErr {
+		t.Errorf(expectedDifferentError, expectedErr, err.Error())
+	}
+}
+
+func TestCloneURL(t *testing.T) {
+	if url := cloneURL("owner/repo", "https"); url != "https://github.com/owner/repo.git" {
+		t.Errorf(expectedDifferentError, "https://github.com/owner/repo.git", url)
+	}
+
+	if url := cloneURL("owner/repo", "ssh"); url != "git@github.com:owner/repo.git" {
+		t.Errorf(expectedDifferentError, "git@github.com:owner/repo.git", url)
+	}
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+var (
+	markdownImage  = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	markdownLink   = regexp.MustCompile(`\[([^\]]+)\]\(([^)]+)\)`)
+	markdownBold   = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	markdownCode   = regexp.MustCompile("`([^`]+)`")
+	markdownBullet = regexp.MustCompile(`^(\s*)[-*+] `)
+)
+
+// renderMarkdown renders the markdown constructs most READMEs rely on for the
+// terminal. HTML lines are dropped and images are replaced by their alt text.
+func renderMarkdown(text string) string {
+	heading := color.New(color.FgMagenta, color.Bold)
+	code := color.New(color.FgCyan)
+	bold := color.New(color.Bold)
+
+	var out strings.Builder
+	inFence := false
+	blank := true
+
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			out.WriteString(code.Sprint("    "+line) + "\n")
+			blank = false
+			continue
+		}
+
+		if trimmed == "" || strings.HasPrefix(trimmed, "<") {
+			if trimmed == "" && !blank {
+				out.WriteString("\n")
+				blank = true
+			}
+			continue
+		}
+		blank = false
+
+		if strings.HasPrefix(trimmed, "#") {
+			title := strings.TrimSpace(strings.TrimLeft(trimmed, "#"))
+			out.WriteString(heading.Sprint(inlineMarkdown(title, code, bold, false)) + "\n")
+			continue
+		}
+
+		line = markdownBullet.ReplaceAllString(line, "$1• ")
+		out.WriteString(inlineMarkdown(line, code, bold, true) + "\n")
+	}
+
+	return strings.TrimRight(out.String(), "\n") + "\n"
+}
+
+func inlineMarkdown(line string, code *color.Color, bold *color.Color, styled bool) string {
+	line = markdownImage.ReplaceAllString(line, "$1")
+	line = markdownLink.ReplaceAllString(line, "$1 ($2)")
+	line = markdownBold.ReplaceAllStringFunc(line, func(match string) string {
+		text := match[2 : len(match)-2]
+		if !styled {
+			return text
+		}
+		return bold.Sprint(text)
+	})
+	line = markdownCode.ReplaceAllStringFunc(line, func(match string) string {
+		text := match[1 : len(match)-1]
+		if !styled {
+			return text
+		}
+		return code.Sprint(text)
+	})
+
+	return line
+}
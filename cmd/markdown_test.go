@@ -0,0 +1,14 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	readme := "# GG\n\n<p align=\"center\"><img src=\"logo.png\"></p>\n\n![build](https://example.com/badge.svg) A **fast** CLI, see [docs](https://example.com).\n\n\n- Run `gg repo view`\n  * nested\n\n```bash\ngo install\n```\n"
+
+	expected := "GG\n\nbuild A fast CLI, see docs (https://example.com).\n\n• Run gg repo view\n  • nested\n\n    go install\n"
+	if output := renderMarkdown(readme); output != expected {
+		t.Errorf(expectedDifferentError, expected, output)
+	}
+}
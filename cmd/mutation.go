@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"github.com/carolinafsilva/go-github-cli/api"
+	"github.com/spf13/cobra"
+)
+
+// applyMutations performs the planned API calls, or only prints them when
+// --dry-run is set. It reports whether the calls were performed.
+func applyMutations(cmd *cobra.Command, mutations []*api.Mutation) bool {
+	if dryRun {
+		if len(mutations) == 0 {
+			cmd.Println("No changes to apply.")
+		}
+		for _, mutation := range mutations {
+			cmd.Println(mutation)
+		}
+		return false
+	}
+
+	if err := api.ApplyMutations(mutations); err != nil {
+		cmd.Println(err)
+		return false
+	}
+
+	return true
+}
@@ -1,16 +1,43 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/carolinafsilva/go-github-cli/api"
 	"github.com/fatih/color"
+	"github.com/google/go-github/v55/github"
 	"github.com/spf13/cobra"
 )
 
 var (
-	size   int
-	status bool
+	size            int
+	status          bool
+	commentBody     string
+	dryRun          bool
+	editTitle       string
+	editBody        string
+	editBase        string
+	addLabels       []string
+	removeLabels    []string
+	addAssignees    []string
+	removeAssignees []string
+	addReviewers    []string
+	removeReviewers []string
+	milestone       int
+	draft           bool
+	ready           bool
+	watchChecks     bool
+	dashboardSize   int
+	reviews         bool
+	backend         string
 )
 
+var errChecksFailed = errors.New("some checks were not successful")
+
 var prCmd = &cobra.Command{
 	Use:   "pr <command> [flags]",
 	Short: "Get information about Github Pull Requests",
@@ -43,55 +70,516 @@ var prAuthorCmd = &cobra.Command{
 var prRepoCmd = &cobra.Command{
 	Use:   "repo <owner/repo> [flags]",
 	Short: "Get Pull Request information by repository",
-	Long:  `The repo subcommand within the pr command allows you to filter and list pull requests on GitHub by the repository name. You can use this subcommand to view pull requests associated with a particular repository.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `The repo subcommand within the pr command allows you to filter and list pull requests on GitHub by the repository name. You can use this subcommand to view pull requests associated with a particular repository.
+
+--status: Show the CI state of each pull request.
+--reviews: Show the review decision, approvals, unresolved threads and requested reviewers of each pull request.
+--backend: Use the "rest" or "graphql" API, defaults to GG_API_BACKEND or "rest". The graphql backend fetches everything in a single query per page and falls back to rest when the server does not support the required fields.`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		repoPath := args[0]
 
-		if status {
-			prs, err := api.ListPRsByRepoWithStatus(repoPath, size)
-			if err != nil {
-				cmd.Println(err)
-				return
+		prs, err := api.ListPRsByRepoWithDetails(repoPath, size, backend, api.PRListOptions{Status: status, Reviews: reviews})
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		for i, pr := range prs {
+			fg.Fprintf(cmd.OutOrStdout(), "%3d. ", i+1)
+			magenta.Fprintf(cmd.OutOrStdout(), "%s", *pr.PR.CreatedAt)
+			if status {
+				statusColor(pr.Status).Fprintf(cmd.OutOrStdout(), "  %s  ", pr.Status)
+			} else {
+				fg.Fprint(cmd.OutOrStdout(), " ")
+			}
+			if reviews {
+				printPRReviews(cmd, pr)
 			}
+			fg.Fprintf(cmd.OutOrStdout(), "%s\n", *pr.PR.Title)
+		}
+	},
+}
+
+func printPRReviews(cmd *cobra.Command, pr *api.PRWithStatus) {
+	requested := append(append([]string{}, pr.RequestedReviewers...), pr.RequestedTeams...)
+	if len(requested) == 0 {
+		requested = []string{"-"}
+	}
+
+	reviewColor(pr.ReviewDecision).Fprintf(cmd.OutOrStdout(), "%-17s ", pr.ReviewDecision)
+	fg.Fprintf(cmd.OutOrStdout(), "%2d approved  %2d unresolved  ", pr.Approvals, pr.UnresolvedThreads)
+	fg.Fprintf(cmd.OutOrStdout(), "%s  ", strings.Join(requested, ","))
+}
+
+var prCommentCmd = &cobra.Command{
+	Use:   "comment <number> [flags]",
+	Short: "Comment on a Pull Request",
+	Long:  `The comment subcommand within the pr command posts a comment on a pull request's conversation. The repository is taken from the --repo flag or, when omitted, from the origin remote of the current git repository.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		number, err := parsePRNumber(args[0])
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
 
-			for i, pr := range prs {
-				statusColor := color.New(color.Bold)
-				if pr.Status == "success" {
-					statusColor.Add(color.FgGreen)
-				} else if pr.Status == "pending" {
-					statusColor.Add(color.FgYellow)
-				} else {
-					statusColor.Add(color.FgRed)
-				}
-
-				fg.Fprintf(cmd.OutOrStdout(), "%3d. ", i+1)
-				magenta.Fprintf(cmd.OutOrStdout(), "%s", *pr.PR.CreatedAt)
-				statusColor.Fprintf(cmd.OutOrStdout(), "  %s  ", pr.Status)
-				fg.Fprintf(cmd.OutOrStdout(), "%s\n", *pr.PR.Title)
+		repoPath, err := resolveRepoPath()
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		comment, err := api.CreatePRComment(repoPath, number, commentBody)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		cmd.Println(comment.GetHTMLURL())
+	},
+}
+
+var prCommentsCmd = &cobra.Command{
+	Use:   "comments <number> [flags]",
+	Short: "Show a Pull Request's conversation",
+	Long:  `The comments subcommand within the pr command lists the comments and review comments of a pull request in chronological order, with replies to review comments grouped under the comment they answer.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		number, err := parsePRNumber(args[0])
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		repoPath, err := resolveRepoPath()
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		comments, err := api.ListPRComments(repoPath, number)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		if len(comments) == 0 {
+			cmd.Println("The pull request does not have comments.")
+			return
+		}
+
+		for _, comment := range comments {
+			printPRComment(cmd, comment, "")
+			for _, reply := range comment.Replies {
+				printPRComment(cmd, reply, "    ")
 			}
-		} else {
-			prs, err := api.ListPRsByRepo(repoPath, size)
+		}
+	},
+}
+
+var prEditCmd = &cobra.Command{
+	Use:   "edit <number> [flags]",
+	Short: "Edit a Pull Request",
+	Long: `The edit subcommand within the pr command changes a pull request's title, body or base branch, adds or removes labels, assignees and reviewers, sets its milestone and converts it between draft and ready for review. Teams are requested as reviewers using the <org/team> format.
+
+--dry-run: Show the API calls that would be made without performing them.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		edit := &api.PREdit{
+			AddLabels:       addLabels,
+			RemoveLabels:    removeLabels,
+			AddAssignees:    addAssignees,
+			RemoveAssignees: removeAssignees,
+			AddReviewers:    addReviewers,
+			RemoveReviewers: removeReviewers,
+		}
+		if cmd.Flags().Changed("title") {
+			edit.Title = &editTitle
+		}
+		if cmd.Flags().Changed("body") {
+			edit.Body = &editBody
+		}
+		if cmd.Flags().Changed("base") {
+			edit.Base = &editBase
+		}
+		if cmd.Flags().Changed("milestone") {
+			edit.Milestone = &milestone
+		}
+		if draft || ready {
+			edit.Draft = &draft
+		}
+
+		runPRMutations(cmd, args[0], func(repoPath string, number int) ([]*api.Mutation, error) {
+			return api.PlanPREdit(repoPath, number, edit)
+		})
+	},
+}
+
+var prCloseCmd = &cobra.Command{
+	Use:   "close <number> [flags]",
+	Short: "Close a Pull Request",
+	Long:  `The close subcommand within the pr command closes an open pull request without merging it.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runPRMutations(cmd, args[0], func(repoPath string, number int) ([]*api.Mutation, error) {
+			return api.PlanPRStateChange(repoPath, number, "closed")
+		})
+	},
+}
+
+var prReopenCmd = &cobra.Command{
+	Use:   "reopen <number> [flags]",
+	Short: "Reopen a Pull Request",
+	Long:  `The reopen subcommand within the pr command reopens a closed pull request.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runPRMutations(cmd, args[0], func(repoPath string, number int) ([]*api.Mutation, error) {
+			return api.PlanPRStateChange(repoPath, number, "open")
+		})
+	},
+}
+
+var prChecksCmd = &cobra.Command{
+	Use:   "checks <number> [flags]",
+	Short: "Show the CI checks of a Pull Request",
+	Long: `The checks subcommand within the pr command lists every check run and commit status reported for the head commit of a pull request, with its state, duration and link. The command exits with a non-zero status when any check fails.
+
+--watch: Refresh the checks in place until all of them finish.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		number, err := parsePRNumber(args[0])
+		if err != nil {
+			return err
+		}
+
+		repoPath, err := resolveRepoPath()
+		if err != nil {
+			return err
+		}
+
+		var checks []*api.Check
+		poll := func() (string, string, bool, error) {
+			checks, err = api.ListPRChecks(repoPath, number)
 			if err != nil {
-				cmd.Println(err)
-				return
+				return "", "", false, err
+			}
+
+			return renderChecks(checks), checksState(checks), checksDone(checks), nil
+		}
+
+		if watchChecks {
+			err = watch(cmd.OutOrStdout(), poll)
+		} else {
+			var frame string
+			frame, _, _, err = poll()
+			fg.Fprint(cmd.OutOrStdout(), frame)
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, check := range checks {
+			if check.Failed() {
+				return errChecksFailed
+			}
+		}
+
+		return nil
+	},
+}
+
+var prStatusCmd = &cobra.Command{
+	Use:   "status [flags]",
+	Short: "Show your Pull Request dashboard",
+	Long:  `The status subcommand within the pr command shows the open pull requests you authored, the ones requesting your review and the ones assigned to you across all repositories, with their CI state, review decision and time since their last update.`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		dashboard, err := api.GetPRDashboard(dashboardSize)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		magentaUnderline := color.New(color.FgMagenta, color.Underline)
+		sections := []struct {
+			title string
+			prs   []*api.DashboardPR
+		}{
+			{"Created by you", dashboard.Authored},
+			{"Requesting a review from you", dashboard.ReviewRequested},
+			{"Assigned to you", dashboard.Assigned},
+		}
+
+		for i, section := range sections {
+			if i > 0 {
+				fg.Fprintln(cmd.OutOrStdout())
+			}
+			magentaUnderline.Fprintln(cmd.OutOrStdout(), section.title+":")
+
+			if len(section.prs) == 0 {
+				fg.Fprintln(cmd.OutOrStdout(), "  No open pull requests")
+				continue
 			}
 
-			for i, pr := range prs {
-				fg.Fprintf(cmd.OutOrStdout(), "%3d. ", i+1)
-				magenta.Fprintf(cmd.OutOrStdout(), "%s ", *pr.CreatedAt)
-				fg.Fprintf(cmd.OutOrStdout(), "%s\n", *pr.Title)
+			for _, pr := range section.prs {
+				magenta.Fprintf(cmd.OutOrStdout(), "  %s#%d ", pr.RepoPath, pr.Number)
+				statusColor(pr.Status).Fprintf(cmd.OutOrStdout(), "%-8s ", pr.Status)
+				reviewColor(pr.ReviewDecision).Fprintf(cmd.OutOrStdout(), "%-17s ", pr.ReviewDecision)
+				fg.Fprintf(cmd.OutOrStdout(), "%4s  %s\n", formatAge(time.Since(pr.UpdatedAt)), pr.Title)
 			}
 		}
 	},
 }
 
+func statusColor(status string) *color.Color {
+	statusColor := color.New(color.Bold)
+	if status == "success" {
+		statusColor.Add(color.FgGreen)
+	} else if status == "pending" {
+		statusColor.Add(color.FgYellow)
+	} else {
+		statusColor.Add(color.FgRed)
+	}
+
+	return statusColor
+}
+
+func reviewColor(decision string) *color.Color {
+	switch decision {
+	case "approved":
+		return color.New(color.FgGreen)
+	case "changes_requested":
+		return color.New(color.FgRed)
+	}
+
+	return color.New(color.FgYellow)
+}
+
+func formatAge(age time.Duration) string {
+	switch {
+	case age < time.Hour:
+		return fmt.Sprintf("%dm", int(age.Minutes()))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(age.Hours()))
+	case age < 30*24*time.Hour:
+		return fmt.Sprintf("%dd", int(age.Hours()/24))
+	}
+
+	return fmt.Sprintf("%dmo", int(age.Hours()/24/30))
+}
+
+func checksDone(checks []*api.Check) bool {
+	for _, check := range checks {
+		if !check.Done() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// checksState lists the name and state of every check, leaving out the
+// durations that change on every poll.
+func checksState(checks []*api.Check) string {
+	var state strings.Builder
+	for _, check := range checks {
+		state.WriteString(fmt.Sprintf("%s %s\n", check.Name, check.State))
+	}
+
+	return state.String()
+}
+
+func renderChecks(checks []*api.Check) string {
+	if len(checks) == 0 {
+		return "The pull request does not have checks.\n"
+	}
+
+	var passed, failed, pending, skipped int
+	width := 0
+	for _, check := range checks {
+		switch {
+		case !check.Done():
+			pending++
+		case check.Failed():
+			failed++
+		case check.State == "success":
+			passed++
+		default:
+			skipped++
+		}
+
+		if len(check.Name) > width {
+			width = len(check.Name)
+		}
+	}
+
+	var frame strings.Builder
+	frame.WriteString(fmt.Sprintf("%d successful, %d failing, %d pending, %d skipped\n\n", passed, failed, pending, skipped))
+
+	for _, check := range checks {
+		stateColor := color.New(color.Bold)
+		symbol := "-"
+		if !check.Done() {
+			stateColor.Add(color.FgYellow)
+			symbol = "*"
+		} else if check.Failed() {
+			stateColor.Add(color.FgRed)
+			symbol = "X"
+		} else if check.State == "success" {
+			stateColor.Add(color.FgGreen)
+			symbol = "✓"
+		}
+
+		frame.WriteString(stateColor.Sprintf("%s %-9s ", symbol, check.State))
+		frame.WriteString(fg.Sprintf("%-*s %8s  ", width, check.Name, check.Duration()))
+		frame.WriteString(magenta.Sprintf("%s\n", check.URL))
+	}
+
+	return frame.String()
+}
+
+func runPRMutations(cmd *cobra.Command, arg string, plan func(repoPath string, number int) ([]*api.Mutation, error)) {
+	number, err := parsePRNumber(arg)
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+
+	repoPath, err := resolveRepoPath()
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+
+	mutations, err := plan(repoPath, number)
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+
+	if !applyMutations(cmd, mutations) {
+		return
+	}
+
+	pr, err := api.GetPR(repoPath, number)
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+
+	printPRState(cmd, pr)
+}
+
+func printPRState(cmd *cobra.Command, pr *github.PullRequest) {
+	state := pr.GetState()
+	if pr.GetMerged() {
+		state = "merged"
+	} else if pr.GetDraft() && state == "open" {
+		state = "draft"
+	}
+
+	var labels, assignees, reviewers []string
+	for _, label := range pr.Labels {
+		labels = append(labels, label.GetName())
+	}
+	for _, assignee := range pr.Assignees {
+		assignees = append(assignees, assignee.GetLogin())
+	}
+	for _, reviewer := range pr.RequestedReviewers {
+		reviewers = append(reviewers, reviewer.GetLogin())
+	}
+	for _, team := range pr.RequestedTeams {
+		reviewers = append(reviewers, team.GetSlug())
+	}
+
+	magenta.Fprintf(cmd.OutOrStdout(), "#%d ", pr.GetNumber())
+	fg.Fprintf(cmd.OutOrStdout(), "%s\n", pr.GetTitle())
+	fg.Fprintf(cmd.OutOrStdout(), "State:     %s\n", state)
+	fg.Fprintf(cmd.OutOrStdout(), "Base:      %s\n", pr.GetBase().GetRef())
+	fg.Fprintf(cmd.OutOrStdout(), "Labels:    %s\n", strings.Join(labels, ", "))
+	fg.Fprintf(cmd.OutOrStdout(), "Assignees: %s\n", strings.Join(assignees, ", "))
+	fg.Fprintf(cmd.OutOrStdout(), "Reviewers: %s\n", strings.Join(reviewers, ", "))
+	fg.Fprintf(cmd.OutOrStdout(), "Milestone: %s\n", pr.GetMilestone().GetTitle())
+	fg.Fprintf(cmd.OutOrStdout(), "%s\n", pr.GetHTMLURL())
+}
+
+func parsePRNumber(arg string) (int, error) {
+	number, err := strconv.Atoi(arg)
+	if err != nil || number <= 0 {
+		return 0, fmt.Errorf("invalid pull request number '%s'", arg)
+	}
+
+	return number, nil
+}
+
+func printPRComment(cmd *cobra.Command, comment *api.PRComment, indent string) {
+	fg.Fprint(cmd.OutOrStdout(), indent)
+	magenta.Fprintf(cmd.OutOrStdout(), "%s ", comment.Author)
+	fg.Fprintf(cmd.OutOrStdout(), "%s", comment.CreatedAt)
+	if comment.Review && indent == "" {
+		fg.Fprintf(cmd.OutOrStdout(), " %s:%d", comment.Path, comment.Line)
+	}
+	if comment.Outdated {
+		color.New(color.FgYellow).Fprint(cmd.OutOrStdout(), " outdated")
+	}
+	if comment.Resolved {
+		color.New(color.FgGreen).Fprint(cmd.OutOrStdout(), " resolved")
+	}
+	fg.Fprintln(cmd.OutOrStdout())
+
+	for _, line := range strings.Split(strings.TrimSpace(comment.Body), "\n") {
+		fg.Fprintf(cmd.OutOrStdout(), "%s  %s\n", indent, strings.TrimRight(line, "\r"))
+	}
+	fg.Fprintln(cmd.OutOrStdout())
+}
+
 func init() {
 	rootCmd.AddCommand(prCmd)
 	prCmd.AddCommand(prAuthorCmd)
 	prCmd.AddCommand(prRepoCmd)
+	prCmd.AddCommand(prCommentCmd)
+	prCmd.AddCommand(prCommentsCmd)
+	prCmd.AddCommand(prEditCmd)
+	prCmd.AddCommand(prCloseCmd)
+	prCmd.AddCommand(prReopenCmd)
+	prCmd.AddCommand(prChecksCmd)
+	prCmd.AddCommand(prStatusCmd)
 
 	prAuthorCmd.Flags().IntVarP(&size, "size", "S", 30, "Number of results to return")
 	prRepoCmd.Flags().BoolVar(&status, "status", false, "Show the state of the PRs in the Workflow")
 	prRepoCmd.Flags().IntVarP(&size, "size", "S", 30, "Number of results to return")
+	prRepoCmd.Flags().BoolVar(&reviews, "reviews", false, "Show the review state of the PRs")
+	prRepoCmd.Flags().StringVar(&backend, "backend", "", "API backend to use, rest or graphql")
+	prCommentCmd.Flags().StringVarP(&repoFlag, "repo", "R", "", "Repository in the <owner/repo> format")
+	prCommentCmd.Flags().StringVarP(&commentBody, "body", "b", "", "Text of the comment")
+	prCommentCmd.MarkFlagRequired("body")
+	prCommentsCmd.Flags().StringVarP(&repoFlag, "repo", "R", "", "Repository in the <owner/repo> format")
+
+	prEditCmd.Flags().StringVarP(&repoFlag, "repo", "R", "", "Repository in the <owner/repo> format")
+	prEditCmd.Flags().StringVarP(&editTitle, "title", "t", "", "New title")
+	prEditCmd.Flags().StringVarP(&editBody, "body", "b", "", "New body")
+	prEditCmd.Flags().StringVarP(&editBase, "base", "B", "", "New base branch")
+	prEditCmd.Flags().StringSliceVar(&addLabels, "add-label", nil, "Labels to add")
+	prEditCmd.Flags().StringSliceVar(&removeLabels, "remove-label", nil, "Labels to remove")
+	prEditCmd.Flags().StringSliceVar(&addAssignees, "add-assignee", nil, "Logins to assign")
+	prEditCmd.Flags().StringSliceVar(&removeAssignees, "remove-assignee", nil, "Logins to unassign")
+	prEditCmd.Flags().StringSliceVar(&addReviewers, "add-reviewer", nil, "Logins or <org/team> to request a review from")
+	prEditCmd.Flags().StringSliceVar(&removeReviewers, "remove-reviewer", nil, "Logins or <org/team> to remove from the requested reviewers")
+	prEditCmd.Flags().IntVarP(&milestone, "milestone", "m", 0, "Milestone number, 0 removes the milestone")
+	prEditCmd.Flags().BoolVar(&draft, "draft", false, "Convert to draft")
+	prEditCmd.Flags().BoolVar(&ready, "ready", false, "Mark as ready for review")
+	prEditCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the API calls without performing them")
+	prEditCmd.MarkFlagsMutuallyExclusive("draft", "ready")
+
+	prCloseCmd.Flags().StringVarP(&repoFlag, "repo", "R", "", "Repository in the <owner/repo> format")
+	prCloseCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the API calls without performing them")
+	prReopenCmd.Flags().StringVarP(&repoFlag, "repo", "R", "", "Repository in the <owner/repo> format")
+	prReopenCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the API calls without performing them")
+
+	prChecksCmd.Flags().StringVarP(&repoFlag, "repo", "R", "", "Repository in the <owner/repo> format")
+	prChecksCmd.Flags().BoolVarP(&watchChecks, "watch", "w", false, "Refresh until all checks finish")
+
+	prStatusCmd.Flags().IntVarP(&dashboardSize, "size", "S", 10, "Number of pull requests to show per section")
 }
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/carolinafsilva/go-github-cli/api"
 	"github.com/joho/godotenv"
@@ -23,7 +25,7 @@ func TestPrCmdPrintHelpMenu(t *testing.T) {
 
 	args := []string{"--help", "-h", "help", "", "invalidcmd"}
 
-	expectedOutput := "The pr command in GG is designed to retrieve essential pull request information from GitHub.\n\tYou can use this command to filter and display pull requests based on different criteria such as the author or repository\n\nUsage:\n  gg pr [command]\n\nAvailable Commands:\n  author      Get Pull Request information by author\n  repo        Get Pull Request information by repository\n\nFlags:\n  -h, --help   help for pr\n\nUse \"gg pr [command] --help\" for more information about a command.\n"
+	expectedOutput := "The pr command in GG is designed to retrieve essential pull request information from GitHub.\n\tYou can use this command to filter and display pull requests based on different criteria such as the author or repository\n\nUsage:\n  gg pr [command]\n\nAvailable Commands:\n  author      Get Pull Request information by author\n  checks      Show the CI checks of a Pull Request\n  close       Close a Pull Request\n  comment     Comment on a Pull Request\n  comments    Show a Pull Request's conversation\n  edit        Edit a Pull Request\n  reopen      Reopen a Pull Request\n  repo        Get Pull Request information by repository\n  status      Show your Pull Request dashboard\n\nFlags:\n  -h, --help   help for pr\n\nUse \"gg pr [command] --help\" for more information about a command.\n"
 
 	for _, arg := range args {
 		cmd.SetArgs([]string{"pr", arg})
@@ -322,3 +324,149 @@ func TestPrRepoCmdWithValidRepoPathAndStatusFlag(t *testing.T) {
 		cmd.SetOut(nil)
 	})
 }
+
+func TestPrCommentCmdWithNoArgs(t *testing.T) {
+	cmd := rootCmd
+
+	cmd.SetArgs([]string{"pr", "comment", "--body", "hello"})
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal(expectedErrorGotNil)
+	}
+
+	expectedErr := "accepts 1 arg(s), received 0"
+	if err.Error() != expectedErr {
+		t.Errorf(expectedDifferentError, expectedErr, err.Error())
+	}
+}
+
+func TestPrCommentsCmdWithInvalidNumber(t *testing.T) {
+	cmd := rootCmd
+
+	var output bytes.Buffer
+	cmd.SetOut(&output)
+
+	cmd.SetArgs([]string{"pr", "comments", "abc", "--repo", "carolinafsilva/go-github-cli"})
+
+	err := cmd.Execute()
+	if err != nil {
+		t.Errorf(expectedNoError, err)
+	}
+
+	expectedMsg := "invalid pull request number 'abc'\n"
+	if output.String() != expectedMsg {
+		t.Errorf(expectedDifferentError, expectedMsg, output.String())
+	}
+
+	t.Cleanup(func() {
+		cmd.SetOut(nil)
+		repoFlag = ""
+	})
+}
+
+func TestPrEditCmdWithNoArgs(t *testing.T) {
+	cmd := rootCmd
+
+	cmd.SetArgs([]string{"pr", "edit"})
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal(expectedErrorGotNil)
+	}
+
+	expectedErr := "accepts 1 arg(s), received 0"
+	if err.Error() != expectedErr {
+		t.Errorf(expectedDifferentError, expectedErr, err.Error())
+	}
+}
+
+func TestPrCloseCmdWithInvalidNumber(t *testing.T) {
+	cmd := rootCmd
+
+	var output bytes.Buffer
+	cmd.SetOut(&output)
+
+	cmd.SetArgs([]string{"pr", "close", "0", "--repo", "carolinafsilva/go-github-cli", "--dry-run"})
+
+	err := cmd.Execute()
+	if err != nil {
+		t.Errorf(expectedNoError, err)
+	}
+
+	expectedMsg := "invalid pull request number '0'\n"
+	if output.String() != expectedMsg {
+		t.Errorf(expectedDifferentError, expectedMsg, output.String())
+	}
+
+	t.Cleanup(func() {
+		cmd.SetOut(nil)
+		repoFlag = ""
+		dryRun = false
+	})
+}
+
+func TestRenderChecksSummary(t *testing.T) {
+	checks := []*api.Check{
+		{Name: "build", State: "success"},
+		{Name: "lint", State: "failure"},
+		{Name: "test", State: "pending"},
+		{Name: "deploy", State: "skipped"},
+	}
+
+	frame := renderChecks(checks)
+
+	expectedSummary := "1 successful, 1 failing, 1 pending, 1 skipped\n"
+	if !strings.HasPrefix(frame, expectedSummary) {
+		t.Errorf(expectedDifferentError, expectedSummary, frame)
+	}
+
+	if checksDone(checks) {
+		t.Error("expected checks with a pending check not to be done")
+	}
+}
+
+func TestChecksStateIgnoresDurations(t *testing.T) {
+	started := time.Now().Add(-time.Minute)
+	checks := []*api.Check{{Name: "test", State: "pending", StartedAt: started}}
+
+	state := checksState(checks)
+	checks[0].StartedAt = started.Add(-time.Minute)
+
+	if checksState(checks) != state {
+		t.Errorf(expectedDifferentError, state, checksState(checks))
+	}
+
+	checks[0].State = "success"
+	if checksState(checks) == state {
+		t.Error("expected the state to change when a check finishes")
+	}
+}
+
+func TestPrStatusCmdWithArgs(t *testing.T) {
+	cmd := rootCmd
+
+	cmd.SetArgs([]string{"pr", "status", "carolinafsilva"})
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal(expectedErrorGotNil)
+	}
+
+	expectedErr := "unknown command \"carolinafsilva\" for \"gg pr status\""
+	if err.Error() != expectedErr {
+		t.Errorf(expectedDifferentError, expectedErr, err.Error())
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	ages := map[time.Duration]string{
+		5 * time.Minute:     "5m",
+		3 * time.Hour:       "3h",
+		50 * time.Hour:      "2d",
+		65 * 24 * time.Hour: "2mo",
+	}
+
+	for age, expected := range ages {
+		if formatAge(age) != expected {
+			t.Errorf(expectedDifferentError, expected, formatAge(age))
+		}
+	}
+}
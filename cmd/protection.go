@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/carolinafsilva/go-github-cli/api"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	protectionFile      string
+	protectionConfirmed bool
+)
+
+var repoProtectionCmd = &cobra.Command{
+	Use:   "protection <command> [flags]",
+	Short: "Inspect and apply branch protection and rulesets",
+	Long:  `The protection subcommand within the repo command dumps the branch protection and the rulesets of a repository as YAML, and applies a desired YAML state so the same protection can be enforced across repositories.`,
+}
+
+var repoProtectionGetCmd = &cobra.Command{
+	Use:   "get <owner/repo> <branch> [flags]",
+	Short: "Print the protection of a branch as YAML",
+	Long:  `The get subcommand within the protection command prints the protection of a branch and the rulesets of the repository that target it as YAML, in the format accepted by apply.`,
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		state, err := api.GetProtectionState(args[0], args[1])
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		content, err := api.MarshalProtectionState(state)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		cmd.Print(content)
+	},
+}
+
+var repoProtectionApplyCmd = &cobra.Command{
+	Use:   "apply <owner/repo> <branch> [flags]",
+	Short: "Apply a YAML protection state to a branch",
+	Long: `The apply subcommand within the protection command changes the protection of a branch and the rulesets of the repository to match a YAML file, printing the difference first and asking to type the repository name to confirm. An empty protection removes the branch protection, and rulesets that are not in the file are left as they are.
+
+--file: YAML file in the format printed by get, or - to read it from stdin, which needs --yes.
+--yes: Skip the confirmation prompt.
+--dry-run: Show the difference and the API calls without performing them.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if protectionFile == "-" && !dryRun && !protectionConfirmed {
+			cmd.Println("--yes is required to apply a protection file read from stdin")
+			return
+		}
+
+		var content []byte
+		var err error
+		if protectionFile == "-" {
+			content, err = io.ReadAll(cmd.InOrStdin())
+		} else {
+			content, err = os.ReadFile(protectionFile)
+		}
+		if err != nil {
+			cmd.Printf("could not read the protection file '%s'\n", protectionFile)
+			return
+		}
+
+		desired, err := api.ParseProtectionState(content)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		plan, err := api.PlanProtectionApply(args[0], args[1], desired)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		if len(plan.Mutations) == 0 {
+			cmd.Printf("The protection of %s in %s is already up to date.\n", args[1], args[0])
+			return
+		}
+
+		current, err := api.MarshalProtectionState(plan.Current)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+		wanted, err := api.MarshalProtectionState(plan.Desired)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		printDiff(cmd, diffLines(current, wanted))
+		cmd.Println()
+
+		if !dryRun && !protectionConfirmed && !confirmRepoName(cmd, args[0]) {
+			cmd.Println("The repository name did not match, the protection was not applied.")
+			return
+		}
+
+		if applyMutations(cmd, plan.Mutations) {
+			cmd.Printf("Applied the protection of %s in %s\n", args[1], args[0])
+		}
+	},
+}
+
+// diffLines compares two texts line by line, prefixing removed lines with -,
+// added lines with + and unchanged ones with a space.
+func diffLines(before string, after string) []string {
+	a := strings.Split(strings.TrimSuffix(before, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(after, "\n"), "\n")
+
+	// lengths of the longest common subsequences of the suffixes
+	common := make([][]int, len(a)+1)
+	for i := range common {
+		common[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else if common[i+1][j] >= common[i][j+1] {
+				common[i][j] = common[i+1][j]
+			} else {
+				common[i][j] = common[i][j+1]
+			}
+		}
+	}
+
+	var lines []string
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, "  "+a[i])
+			i++
+			j++
+		case common[i+1][j] >= common[i][j+1]:
+			lines = append(lines, "- "+a[i])
+			i++
+		default:
+			lines = append(lines, "+ "+b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, "- "+a[i])
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, "+ "+b[j])
+	}
+
+	return lines
+}
+
+func printDiff(cmd *cobra.Command, lines []string) {
+	green := color.New(color.FgGreen)
+	red := color.New(color.FgRed)
+
+	for _, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+"):
+			green.Fprintln(cmd.OutOrStdout(), line)
+		case strings.HasPrefix(line, "-"):
+			red.Fprintln(cmd.OutOrStdout(), line)
+		default:
+			fg.Fprintln(cmd.OutOrStdout(), line)
+		}
+	}
+}
+
+func init() {
+	repoCmd.AddCommand(repoProtectionCmd)
+	repoProtectionCmd.AddCommand(repoProtectionGetCmd)
+	repoProtectionCmd.AddCommand(repoProtectionApplyCmd)
+
+	repoProtectionApplyCmd.Flags().StringVarP(&protectionFile, "file", "f", "", "YAML file with the desired protection, - for stdin")
+	repoProtectionApplyCmd.MarkFlagRequired("file")
+	repoProtectionApplyCmd.Flags().BoolVarP(&protectionConfirmed, "yes", "y", false, "Apply without asking for confirmation")
+	repoProtectionApplyCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the API calls without performing them")
+}
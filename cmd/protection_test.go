@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDiffLines(t *testing.T) {
+	before := "protection:\n  enforce_admins: false\n  lock_branch: false\n"
+	after := "protection:\n  enforce_admins: true\n  lock_branch: false\nrulesets: []\n"
+
+	expected := []string{
+		"  protection:",
+		"-   enforce_admins: false",
+		"+   enforce_admins: true",
+		"    lock_branch: false",
+		"+ rulesets: []",
+	}
+
+	lines := diffLines(before, after)
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf(expectedDifferentError, strings.Join(expected, "\n"), strings.Join(lines, "\n"))
+	}
+}
+
+func TestRepoProtectionApplyCmdFromStdinWithoutYes(t *testing.T) {
+	cmd := rootCmd
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+
+	cmd.SetArgs([]string{"repo", "protection", "apply", "acme/api", "main", "--file", "-"})
+	err := cmd.Execute()
+	if err != nil {
+		t.Errorf(expectedNoError, err.Error())
+	}
+
+	expectedOutput := "--yes is required to apply a protection file read from stdin\n"
+	if b.String() != expectedOutput {
+		t.Errorf(expectedDifferentError, expectedOutput, b.String())
+	}
+
+	t.Cleanup(func() {
+		cmd.SetOut(nil)
+		protectionFile = ""
+	})
+}
@@ -1,14 +1,35 @@
 package cmd
 
 import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/carolinafsilva/go-github-cli/api"
 	"github.com/fatih/color"
+	"github.com/google/go-github/v55/github"
 	"github.com/spf13/cobra"
 )
 
 var (
-	owned    bool
-	followed bool
+	owned           bool
+	followed        bool
+	starred         bool
+	watchLevel      string
+	viewWeb         bool
+	forkOrg         string
+	forkClone       bool
+	repoCreate      api.RepoCreate
+	deleteConfirmed bool
+	viewBranch      string
+	repoFilter      api.RepoFilter
+	repoOrg         string
+	repoTeam        string
+	workflowSize    int
+	workflowRef     string
+	workflowFields  []string
 )
 
 var repoCmd = &cobra.Command{
@@ -17,44 +38,360 @@ var repoCmd = &cobra.Command{
 	Long:  `The repo command in GG allows you to interact with GitHub repositories. This command provides subcommands for listing repositories and their workflows.`,
 }
 
-var repoListCmd = &cobra.Command{
-	Use:   "list <username>",
-	Short: "List a user's repositories",
-	Long: `The list subcommand within the repo command lists GitHub repositories based on specified criteria. You can use this command to retrieve repositories owned or followed by a particular user. Additionally, you can filter the results to display only owned or followed repositories by using the respective flags.
+var repoCloneCmd = &cobra.Command{
+	Use:   "clone <owner/repo> [<directory>] [flags]",
+	Short: "Clone a repository",
+	Long: `The clone subcommand within the repo command clones a GitHub repository with the local git, into <directory> when given.
+
+The protocol is https by default, set GG_GIT_PROTOCOL to ssh in the environment or in a .env file to clone over ssh instead.`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		protocol, err := gitProtocol()
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		gitArgs := []string{"clone", cloneURL(args[0], protocol)}
+		gitArgs = append(gitArgs, args[1:]...)
+		if err := runGit(cmd, gitArgs...); err != nil {
+			cmd.Println(err)
+		}
+	},
+}
+
+var repoForkCmd = &cobra.Command{
+	Use:   "fork [<owner/repo>] [flags]",
+	Short: "Fork a repository",
+	Long: `The fork subcommand within the repo command forks a repository, by default the one of the origin remote of the current directory, and waits for the fork to be available.
+
+--org: Fork into an organization instead of your account.
+--clone: Clone the fork and add the forked repository as the upstream remote.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		repoPath, err := repoFromArgs(args)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		protocol, err := gitProtocol()
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		fork, err := api.ForkRepo(repoPath, forkOrg, time.Minute)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		cmd.Printf("Forked %s to %s\n", repoPath, fork.GetFullName())
+		if !forkClone {
+			return
+		}
+
+		if err := runGit(cmd, "clone", cloneURL(fork.GetFullName(), protocol)); err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		if err := runGit(cmd, "-C", fork.GetName(), "remote", "add", "upstream", cloneURL(repoPath, protocol)); err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		cmd.Printf("Added %s as the upstream remote\n", repoPath)
+	},
+}
+
+var repoCreateCmd = &cobra.Command{
+	Use:   "create <name> [flags]",
+	Short: "Create a repository",
+	Long: `The create subcommand within the repo command creates a repository for the authenticated user or an organization, empty or from a template repository.
 
---followed: List only followed repositories.
---owned: List only owned repositories.`,
+--org: Create the repository in an organization.
+--template: Template repository in the <owner/repo> format to create the repository from.
+--visibility: public, private or internal.
+--gitignore, --license, --add-readme: Initialize the repository with a .gitignore template, a license or a README.
+--dry-run: Show the API calls that would be made without performing them.`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		githubUser = args[0]
+		create := repoCreate
+		create.Name = args[0]
+
+		mutations, err := api.PlanRepoCreate(&create)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		if !applyMutations(cmd, mutations) {
+			return
+		}
+
+		owner := create.Org
+		if owner == "" {
+			owner = "your account"
+		}
+		cmd.Printf("Created %s repository %s in %s\n", create.Visibility, create.Name, owner)
+	},
+}
+
+var repoEditCmd = &cobra.Command{
+	Use:   "edit [<owner/repo>] [flags]",
+	Short: "Edit the settings of a repository",
+	Long: `The edit subcommand within the repo command changes the settings of a repository, by default the one of the origin remote of the current directory. Only the settings given as flags that differ from the current ones are changed, and boolean settings are turned off with --flag=false.
+
+--dry-run: Show the API calls that would be made without performing them.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		edit := repoEditFromFlags(cmd)
+		runRepoMutations(cmd, args, "Updated", func(repoPath string) ([]*api.Mutation, error) {
+			return api.PlanRepoEdit(repoPath, edit)
+		})
+	},
+}
+
+var repoArchiveCmd = &cobra.Command{
+	Use:   "archive [<owner/repo>] [flags]",
+	Short: "Archive a repository",
+	Long:  `The archive subcommand within the repo command makes a repository, by default the one of the origin remote of the current directory, read-only.`,
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runRepoMutations(cmd, args, "Archived", func(repoPath string) ([]*api.Mutation, error) {
+			return api.PlanRepoArchive(repoPath, true)
+		})
+	},
+}
 
-		magentaUnderline := magenta.Add(color.Underline)
+var repoUnarchiveCmd = &cobra.Command{
+	Use:   "unarchive [<owner/repo>] [flags]",
+	Short: "Unarchive a repository",
+	Long:  `The unarchive subcommand within the repo command makes an archived repository, by default the one of the origin remote of the current directory, writable again.`,
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runRepoMutations(cmd, args, "Unarchived", func(repoPath string) ([]*api.Mutation, error) {
+			return api.PlanRepoArchive(repoPath, false)
+		})
+	},
+}
+
+var repoRenameCmd = &cobra.Command{
+	Use:   "rename <new-name> [flags]",
+	Short: "Rename a repository",
+	Long: `The rename subcommand within the repo command renames a repository, by default the one of the origin remote of the current directory. GitHub redirects the old name to the new one.
+
+--repo: Repository in the <owner/repo> format, defaults to the origin remote of the current directory.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runRepoMutations(cmd, nil, "Renamed", func(repoPath string) ([]*api.Mutation, error) {
+			return api.PlanRepoRename(repoPath, args[0])
+		})
+	},
+}
+
+var repoDeleteCmd = &cobra.Command{
+	Use:   "delete [<owner/repo>] [flags]",
+	Short: "Delete a repository",
+	Long: `The delete subcommand within the repo command permanently deletes a repository, by default the one of the origin remote of the current directory, after asking to type its name to confirm.
+
+--yes: Skip the confirmation prompt.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runRepoMutations(cmd, args, "Deleted", func(repoPath string) ([]*api.Mutation, error) {
+			if !dryRun && !deleteConfirmed && !confirmRepoName(cmd, repoPath) {
+				return nil, fmt.Errorf("the repository name did not match, '%s' was not deleted", repoPath)
+			}
+			return api.PlanRepoDelete(repoPath)
+		})
+	},
+}
+
+var repoListCmd = &cobra.Command{
+	Use:   "list <username> [flags]",
+	Short: "List the repositories of a user, organization or team",
+	Long: `The list subcommand within the repo command lists GitHub repositories based on specified criteria. You can use this command to retrieve repositories owned or followed by a particular user, or the repositories of an organization or a team. When the given name belongs to an organization, its repositories are listed instead. Additionally, you can filter the results to display only owned or followed repositories by using the respective flags.
+
+--org: List the repositories of an organization.
+--team: List the repositories a team has access to, in the <org/team> format.
+--followed: List only followed repositories, the ones the user watches.
+--owned: List only owned repositories.
+--starred: List the repositories the user starred, with when they were starred, instead.
+--type: List only repositories the user owns, is a member of or collaborates on. Only applies to owned repositories, and collaborator requires the authenticated user.
+--visibility: List only public or private repositories.
+--language, --topic: List only repositories with a primary language or topic.
+--no-archived: Omit archived repositories.
+--source, --fork: List only repositories that are not forks, or only forks.
+--sort: Sort the repositories by stars, pushed, updated or name, or by starred with --starred.
+--direction: Sort direction, asc or desc. Defaults to asc for name and desc otherwise.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if repoOrg != "" || repoTeam != "" {
+			if len(args) > 0 {
+				return fmt.Errorf("--org and --team cannot be combined with a username")
+			}
+			return nil
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		magentaUnderline := color.New(color.FgMagenta, color.Underline)
+
+		org := repoOrg
+		if len(args) > 0 {
+			githubUser = args[0]
+			// fall back to listing a user when the account cannot be looked up
+			if isOrg, err := api.IsOrganization(githubUser); err == nil && isOrg {
+				org = githubUser
+			}
+		}
+
+		if org != "" || repoTeam != "" {
+			if followed || starred {
+				cmd.Println("--followed and --starred only apply to users")
+				return
+			}
+
+			var repos []*github.Repository
+			var err error
+			title := "Team Repositories:"
+			if repoTeam != "" {
+				repos, err = api.GetTeamRepos(repoTeam, size, repoFilter)
+			} else {
+				title = "Organization Repositories:"
+				repos, err = api.GetOrgRepos(org, size, repoFilter)
+			}
+			if err != nil {
+				cmd.Println(err)
+				return
+			}
+
+			magentaUnderline.Fprintln(cmd.OutOrStdout(), title)
+			fg.Fprint(cmd.OutOrStdout(), renderRepos(repos, time.Now()))
+			return
+		}
+
+		if starred {
+			repos, err := api.GetStarredRepos(githubUser, size, repoFilter)
+			if err != nil {
+				cmd.Println(err)
+				return
+			}
+
+			magentaUnderline.Fprintln(cmd.OutOrStdout(), "Starred Repositories:")
+			fg.Fprint(cmd.OutOrStdout(), renderStarredRepos(repos, time.Now()))
+			return
+		}
 
 		if !followed {
-			repos, err := api.GetOwnedRepos(githubUser, size)
+			repos, err := api.GetOwnedRepos(githubUser, size, repoFilter)
 			if err != nil {
 				cmd.Println(err)
 				return
 			}
 
 			magentaUnderline.Fprintln(cmd.OutOrStdout(), "Owned Repositories:")
-			for _, repo := range repos {
-				fg.Fprintln(cmd.OutOrStdout(), *repo.Name)
-			}
+			fg.Fprint(cmd.OutOrStdout(), renderRepos(repos, time.Now()))
 		}
 
 		if !owned {
-			repos, err := api.GetFollowedRepos(githubUser, size)
+			repos, err := api.GetFollowedRepos(githubUser, size, repoFilter)
 			if err != nil {
 				cmd.Println(err)
 				return
 			}
 
 			magentaUnderline.Fprintln(cmd.OutOrStdout(), "Followed Repositories:")
-			for _, repo := range repos {
-				fg.Fprintln(cmd.OutOrStdout(), *repo.Name)
+			fg.Fprint(cmd.OutOrStdout(), renderRepos(repos, time.Now()))
+		}
+	},
+}
+
+var repoStarCmd = &cobra.Command{
+	Use:   "star [<owner/repo>] [flags]",
+	Short: "Star a repository",
+	Long:  `The star subcommand within the repo command stars a repository, by default the one of the origin remote of the current directory.`,
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		setStar(cmd, args, true)
+	},
+}
+
+var repoUnstarCmd = &cobra.Command{
+	Use:   "unstar [<owner/repo>] [flags]",
+	Short: "Unstar a repository",
+	Long:  `The unstar subcommand within the repo command removes the star from a repository, by default the one of the origin remote of the current directory.`,
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		setStar(cmd, args, false)
+	},
+}
+
+var repoWatchCmd = &cobra.Command{
+	Use:   "watch [<owner/repo>] [flags]",
+	Short: "Show or change how you watch a repository",
+	Long: `The watch subcommand within the repo command shows which notifications you get for a repository, by default the one of the origin remote of the current directory, and changes them with --level.
+
+--level: all to be notified of all activity, releases to only be notified of new releases, or ignore to never be notified. The GitHub API cannot set the releases level, so it points to the repository page where it can be chosen. A repository that is not watched is shown as participating.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		repoPath, err := repoFromArgs(args)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		if watchLevel != "" {
+			if err := api.SetWatchLevel(repoPath, watchLevel); err != nil {
+				cmd.Println(err)
+				return
 			}
 		}
+
+		level, err := api.GetWatchLevel(repoPath)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		cmd.Printf("Watching %s: %s\n", repoPath, level)
+	},
+}
+
+var repoViewCmd = &cobra.Command{
+	Use:   "view [<owner/repo>] [flags]",
+	Short: "Show a repository and its README",
+	Long: `The view subcommand within the repo command shows the description, topics, license, homepage, default branch, languages, stats and latest release of a repository, by default the one of the origin remote of the current directory, followed by its README rendered in the terminal.
+
+--web: Open the repository in the browser instead.
+--branch: Show the README of another branch or ref.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		repoPath, err := repoFromArgs(args)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		if viewWeb {
+			url := "https://github.com/" + repoPath
+			if viewBranch != "" {
+				url += "/tree/" + viewBranch
+			}
+			if err := openInBrowser(url); err != nil {
+				cmd.Println(err)
+			}
+			return
+		}
+
+		details, err := api.GetRepoDetails(repoPath, viewBranch)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		fg.Fprint(cmd.OutOrStdout(), renderRepoView(details))
 	},
 }
 
@@ -66,31 +403,472 @@ var repoWorkflowCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		repoPath := args[0]
 
-		workflows, err := api.ListRepoWorkflows(repoPath)
+		workflows, total, err := api.ListWorkflowDetails(repoPath, workflowSize)
 		if err != nil {
 			cmd.Println(err)
 			return
 		}
 
-		if len(workflows.Workflows) == 0 {
+		if len(workflows) == 0 {
 			cmd.Println("The repository does not have workflows.")
 			return
 		}
 
-		for _, workflow := range workflows.Workflows {
-			cmd.Printf("%s\n", workflow.GetName())
+		for i, workflow := range workflows {
+			if i > 0 {
+				cmd.Println()
+			}
+			printWorkflow(cmd, workflow)
+		}
+
+		if len(workflows) < total {
+			cmd.Printf("\nShowing %d of %d workflows, use --size to list more.\n", len(workflows), total)
 		}
+	},
+}
+
+var repoWorkflowEnableCmd = &cobra.Command{
+	Use:   "enable <workflow> [flags]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Enable a workflow",
+	Long:  `The enable subcommand within the workflow command re-enables a workflow that was disabled manually or for inactivity, identified by its name, ID or file name.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		setWorkflowState(cmd, args[0], true)
+	},
+}
 
+var repoWorkflowDisableCmd = &cobra.Command{
+	Use:   "disable <workflow> [flags]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Disable a workflow",
+	Long:  `The disable subcommand within the workflow command disables a workflow, identified by its name, ID or file name, so that none of its triggers start new runs.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		setWorkflowState(cmd, args[0], false)
 	},
 }
 
+var repoWorkflowRunCmd = &cobra.Command{
+	Use:   "run <workflow> [flags]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Dispatch a workflow run",
+	Long: `The run subcommand within the workflow command triggers a workflow_dispatch event for a workflow, identified by its name, ID or file name. Inputs are validated against the inputs declared by the workflow file before the run is requested.
+
+--ref: Branch or tag to run the workflow on, defaults to the repository's default branch.
+-f, --field: Workflow input in the key=value format, can be repeated.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		repoPath, err := resolveRepoPath()
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		values, err := parseFields(workflowFields)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		workflow, ref, err := api.DispatchWorkflow(repoPath, args[0], workflowRef, values)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		cmd.Printf("Created a workflow_dispatch event for %s on %s\n", workflow.GetName(), ref)
+	},
+}
+
+func parseFields(fields []string) (map[string]string, error) {
+	values := make(map[string]string)
+
+	for _, field := range fields {
+		key, value, found := strings.Cut(field, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid field '%s', use the key=value format", field)
+		}
+		values[key] = value
+	}
+
+	return values, nil
+}
+
+// repoEditFromFlags only sets the settings whose flags were given.
+func repoEditFromFlags(cmd *cobra.Command) *api.RepoEdit {
+	addTopics, _ := cmd.Flags().GetStringSlice("add-topic")
+	removeTopics, _ := cmd.Flags().GetStringSlice("remove-topic")
+	edit := &api.RepoEdit{AddTopics: addTopics, RemoveTopics: removeTopics}
+	stringFlags := map[string]**string{
+		"description":    &edit.Description,
+		"homepage":       &edit.Homepage,
+		"default-branch": &edit.DefaultBranch,
+		"visibility":     &edit.Visibility,
+	}
+	for name, field := range stringFlags {
+		if cmd.Flags().Changed(name) {
+			value, _ := cmd.Flags().GetString(name)
+			*field = &value
+		}
+	}
+
+	boolFlags := map[string]**bool{
+		"allow-merge-commit":     &edit.AllowMergeCommit,
+		"allow-squash-merge":     &edit.AllowSquashMerge,
+		"allow-rebase-merge":     &edit.AllowRebaseMerge,
+		"delete-branch-on-merge": &edit.DeleteBranchOnMerge,
+		"enable-issues":          &edit.HasIssues,
+		"enable-projects":        &edit.HasProjects,
+		"enable-wiki":            &edit.HasWiki,
+		"enable-discussions":     &edit.HasDiscussions,
+	}
+	for name, field := range boolFlags {
+		if cmd.Flags().Changed(name) {
+			value, _ := cmd.Flags().GetBool(name)
+			*field = &value
+		}
+	}
+
+	return edit
+}
+
+func runRepoMutations(cmd *cobra.Command, args []string, action string, plan func(repoPath string) ([]*api.Mutation, error)) {
+	repoPath, err := repoFromArgs(args)
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+
+	mutations, err := plan(repoPath)
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+
+	if len(mutations) == 0 && !dryRun {
+		cmd.Printf("%s is already up to date.\n", repoPath)
+		return
+	}
+
+	if applyMutations(cmd, mutations) {
+		cmd.Printf("%s %s\n", action, repoPath)
+	}
+}
+
+// confirmRepoName asks to type the full name of the repository, the way
+// GitHub does before destructive changes.
+func confirmRepoName(cmd *cobra.Command, repoPath string) bool {
+	cmd.Printf("Type %s to confirm: ", repoPath)
+
+	answer, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && answer == "" {
+		return false
+	}
+
+	return strings.TrimSpace(answer) == repoPath
+}
+
+func repoFromArgs(args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+
+	return resolveRepoPath()
+}
+
+func setStar(cmd *cobra.Command, args []string, star bool) {
+	repoPath, err := repoFromArgs(args)
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+
+	if err := api.StarRepo(repoPath, star); err != nil {
+		cmd.Println(err)
+		return
+	}
+
+	if star {
+		cmd.Printf("Starred %s\n", repoPath)
+	} else {
+		cmd.Printf("Unstarred %s\n", repoPath)
+	}
+}
+
+func renderRepoView(details *api.RepoDetails) string {
+	var view strings.Builder
+	repo := details.Repo
+
+	view.WriteString(magenta.Sprintln(repo.GetFullName()))
+	if repo.GetDescription() != "" {
+		view.WriteString(repo.GetDescription() + "\n")
+	}
+	view.WriteString("\n")
+
+	field := func(name string, value string) {
+		if value == "" {
+			value = "-"
+		}
+		view.WriteString(fmt.Sprintf("  %-16s %s\n", name+":", value))
+	}
+
+	var languages []string
+	for _, language := range details.Languages {
+		languages = append(languages, fmt.Sprintf("%s %.1f%%", language.Name, language.Percent))
+	}
+
+	release := ""
+	if details.LatestRelease != nil {
+		release = fmt.Sprintf("%s (%s)", details.LatestRelease.GetTagName(), details.LatestRelease.GetPublishedAt().Format(time.DateOnly))
+	}
+
+	field("Topics", strings.Join(repo.Topics, ", "))
+	field("License", repo.GetLicense().GetName())
+	field("Homepage", repo.GetHomepage())
+	field("Default branch", repo.GetDefaultBranch())
+	field("Languages", strings.Join(languages, ", "))
+	field("Stars", strconv.Itoa(repo.GetStargazersCount()))
+	field("Forks", strconv.Itoa(repo.GetForksCount()))
+	field("Open issues", strconv.Itoa(repo.GetOpenIssuesCount()))
+	field("Contributors", strconv.Itoa(details.Contributors))
+	field("Latest release", release)
+
+	view.WriteString("\n")
+	if details.Readme == "" {
+		view.WriteString("This repository does not have a README.\n")
+	} else {
+		view.WriteString(renderMarkdown(details.Readme))
+	}
+
+	return view.String()
+}
+
+func renderRepos(repos []*github.Repository, now time.Time) string {
+	return renderRepoTable(repos, nil, now)
+}
+
+func renderStarredRepos(starred []*github.StarredRepository, now time.Time) string {
+	repos := make([]*github.Repository, 0, len(starred))
+	starredAt := make([]time.Time, 0, len(starred))
+	for _, star := range starred {
+		repos = append(repos, star.GetRepository())
+		starredAt = append(starredAt, star.GetStarredAt().Time)
+	}
+
+	return renderRepoTable(repos, starredAt, now)
+}
+
+// renderRepoTable adds a column with when each repository was starred when
+// starredAt is given.
+func renderRepoTable(repos []*github.Repository, starredAt []time.Time, now time.Time) string {
+	var table strings.Builder
+	row := "%-40s %-10s %-22s %-12s %6s %6s %6s %-15s %s\n"
+	header := []interface{}{"NAME", "VISIBILITY", "FLAGS", "LANGUAGE", "STARS", "FORKS", "ISSUES", "BRANCH", "PUSHED"}
+	if starredAt != nil {
+		row = "%-40s %-10s %-22s %-12s %6s %6s %6s %-15s %-10s %s\n"
+		header = append(header, "STARRED")
+	}
+
+	table.WriteString(magenta.Sprintf(row, header...))
+	for i, repo := range repos {
+		var flags []string
+		if repo.GetFork() {
+			flags = append(flags, "fork")
+		}
+		if repo.GetArchived() {
+			flags = append(flags, "archived")
+		}
+		if repo.GetIsTemplate() {
+			flags = append(flags, "template")
+		}
+		if len(flags) == 0 {
+			flags = append(flags, "-")
+		}
+
+		language := repo.GetLanguage()
+		if language == "" {
+			language = "-"
+		}
+
+		pushed := "-"
+		if !repo.GetPushedAt().IsZero() {
+			pushed = formatAge(now.Sub(repo.GetPushedAt().Time)) + " ago"
+		}
+
+		columns := []interface{}{
+			repo.GetFullName(),
+			repo.GetVisibility(),
+			strings.Join(flags, ","),
+			language,
+			strconv.Itoa(repo.GetStargazersCount()),
+			strconv.Itoa(repo.GetForksCount()),
+			strconv.Itoa(repo.GetOpenIssuesCount()),
+			repo.GetDefaultBranch(),
+			pushed,
+		}
+		if starredAt != nil {
+			columns = append(columns, formatAge(now.Sub(starredAt[i]))+" ago")
+		}
+
+		table.WriteString(fg.Sprintf(row, columns...))
+	}
+
+	return table.String()
+}
+
+func addRepoEditFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("description", "d", "", "Description of the repository")
+	cmd.Flags().String("homepage", "", "Homepage URL")
+	cmd.Flags().String("default-branch", "", "Default branch")
+	cmd.Flags().String("visibility", "", "Visibility: public, private or internal")
+	cmd.Flags().Bool("allow-merge-commit", false, "Allow merging pull requests with a merge commit")
+	cmd.Flags().Bool("allow-squash-merge", false, "Allow squash merging pull requests")
+	cmd.Flags().Bool("allow-rebase-merge", false, "Allow rebase merging pull requests")
+	cmd.Flags().Bool("delete-branch-on-merge", false, "Delete head branches when pull requests are merged")
+	cmd.Flags().Bool("enable-issues", false, "Enable issues")
+	cmd.Flags().Bool("enable-projects", false, "Enable projects")
+	cmd.Flags().Bool("enable-wiki", false, "Enable the wiki")
+	cmd.Flags().Bool("enable-discussions", false, "Enable discussions")
+	cmd.Flags().StringSlice("add-topic", nil, "Topics to add")
+	cmd.Flags().StringSlice("remove-topic", nil, "Topics to remove")
+}
+
+func addRepoListFlags() {
+	repoListCmd.Flags().IntVarP(&size, "size", "s", 30, "Number of repositories to list")
+	repoListCmd.Flags().BoolVar(&owned, "owned", false, "List only owned repos")
+	repoListCmd.Flags().BoolVar(&followed, "followed", false, "List only followed repos")
+	repoListCmd.Flags().BoolVar(&starred, "starred", false, "List starred repos instead")
+	repoListCmd.MarkFlagsMutuallyExclusive("owned", "followed", "starred")
+	repoListCmd.Flags().StringVar(&repoFilter.Type, "type", "", "Filter by affiliation: owner, member or collaborator")
+	repoListCmd.Flags().StringVar(&repoFilter.Visibility, "visibility", "", "Filter by visibility: all, public or private")
+	repoListCmd.Flags().StringVar(&repoFilter.Language, "language", "", "Filter by primary language")
+	repoListCmd.Flags().StringVar(&repoFilter.Topic, "topic", "", "Filter by topic")
+	repoListCmd.Flags().BoolVar(&repoFilter.NoArchived, "no-archived", false, "Omit archived repositories")
+	repoListCmd.Flags().BoolVar(&repoFilter.Source, "source", false, "List only non-forks")
+	repoListCmd.Flags().BoolVar(&repoFilter.Fork, "fork", false, "List only forks")
+	repoListCmd.MarkFlagsMutuallyExclusive("source", "fork")
+	repoListCmd.Flags().StringVar(&repoOrg, "org", "", "List the repositories of an organization")
+	repoListCmd.Flags().StringVar(&repoTeam, "team", "", "List the repositories of a team, in the <org/team> format")
+	repoListCmd.MarkFlagsMutuallyExclusive("org", "team")
+	repoListCmd.Flags().StringVar(&repoFilter.Sort, "sort", "", "Sort by stars, pushed, updated or name")
+	repoListCmd.Flags().StringVar(&repoFilter.Direction, "direction", "", "Sort direction, asc or desc")
+}
+
+func printWorkflow(cmd *cobra.Command, details *api.WorkflowDetails) {
+	workflow := details.Workflow
+
+	magenta.Fprintf(cmd.OutOrStdout(), "%s", workflow.GetName())
+	fg.Fprintf(cmd.OutOrStdout(), " (%d)\n", workflow.GetID())
+	fg.Fprintf(cmd.OutOrStdout(), "  Path:     %s\n", workflow.GetPath())
+	workflowStateColor(workflow.GetState()).Fprintf(cmd.OutOrStdout(), "  State:    %s\n", workflow.GetState())
+
+	if details.LastRun != nil {
+		state := runState(details.LastRun)
+		conclusionColor(state).Fprintf(cmd.OutOrStdout(), "  Last run: %s", state)
+		fg.Fprintf(cmd.OutOrStdout(), " (%s ago)\n", formatAge(time.Since(details.LastRun.GetCreatedAt().Time)))
+	} else {
+		fg.Fprintln(cmd.OutOrStdout(), "  Last run: never")
+	}
+
+	if len(details.Triggers) > 0 {
+		fg.Fprintf(cmd.OutOrStdout(), "  Triggers: %s\n", strings.Join(details.Triggers, ", "))
+	}
+	fg.Fprintf(cmd.OutOrStdout(), "  Badge:    %s\n", workflow.GetBadgeURL())
+}
+
+func workflowStateColor(state string) *color.Color {
+	if state == "active" {
+		return color.New(color.FgGreen)
+	}
+
+	return color.New(color.FgYellow)
+}
+
+func setWorkflowState(cmd *cobra.Command, selector string, enable bool) {
+	repoPath, err := resolveRepoPath()
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+
+	mutations, err := api.PlanWorkflowStateChange(repoPath, selector, enable)
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+
+	if !dryRun && len(mutations) == 0 {
+		cmd.Println("The workflow is already in that state.")
+		return
+	}
+
+	if !applyMutations(cmd, mutations) {
+		return
+	}
+
+	if enable {
+		cmd.Printf("Enabled workflow %s\n", selector)
+	} else {
+		cmd.Printf("Disabled workflow %s\n", selector)
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(repoCmd)
+	repoCmd.AddCommand(repoArchiveCmd)
+	repoCmd.AddCommand(repoCloneCmd)
+	repoCmd.AddCommand(repoCreateCmd)
+	repoCmd.AddCommand(repoDeleteCmd)
+	repoCmd.AddCommand(repoEditCmd)
+	repoCmd.AddCommand(repoForkCmd)
 	repoCmd.AddCommand(repoListCmd)
+	repoCmd.AddCommand(repoRenameCmd)
+	repoCmd.AddCommand(repoStarCmd)
+	repoCmd.AddCommand(repoUnarchiveCmd)
+	repoCmd.AddCommand(repoUnstarCmd)
+	repoCmd.AddCommand(repoViewCmd)
+	repoCmd.AddCommand(repoWatchCmd)
 	repoCmd.AddCommand(repoWorkflowCmd)
+	repoWorkflowCmd.AddCommand(repoWorkflowRunCmd)
+	repoWorkflowCmd.AddCommand(repoWorkflowEnableCmd)
+	repoWorkflowCmd.AddCommand(repoWorkflowDisableCmd)
 
-	repoListCmd.Flags().IntVarP(&size, "size", "s", 30, "Number of repositories to list")
-	repoListCmd.Flags().BoolVar(&owned, "owned", false, "List only owned repos")
-	repoListCmd.Flags().BoolVar(&followed, "followed", false, "List only followed repos")
-	repoListCmd.MarkFlagsMutuallyExclusive("owned", "followed")
+	addRepoListFlags()
+
+	repoCreateCmd.Flags().StringVarP(&repoCreate.Org, "org", "o", "", "Organization to create the repository in")
+	repoCreateCmd.Flags().StringVarP(&repoCreate.Description, "description", "d", "", "Description of the repository")
+	repoCreateCmd.Flags().StringVarP(&repoCreate.Visibility, "visibility", "v", "private", "Visibility: public, private or internal")
+	repoCreateCmd.Flags().StringVar(&repoCreate.Gitignore, "gitignore", "", "Gitignore template, such as Go")
+	repoCreateCmd.Flags().StringVar(&repoCreate.License, "license", "", "License template, such as mit")
+	repoCreateCmd.Flags().BoolVar(&repoCreate.Readme, "add-readme", false, "Initialize the repository with a README")
+	repoCreateCmd.Flags().StringVarP(&repoCreate.Template, "template", "t", "", "Template repository in the <owner/repo> format")
+	repoCreateCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the API calls without performing them")
+
+	addRepoEditFlags(repoEditCmd)
+	repoEditCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the API calls without performing them")
+
+	repoArchiveCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the API calls without performing them")
+	repoUnarchiveCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the API calls without performing them")
+	repoRenameCmd.Flags().StringVarP(&repoFlag, "repo", "R", "", "Repository in the <owner/repo> format")
+	repoRenameCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the API calls without performing them")
+	repoDeleteCmd.Flags().BoolVarP(&deleteConfirmed, "yes", "y", false, "Delete without asking for confirmation")
+	repoDeleteCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the API calls without performing them")
+
+	repoForkCmd.Flags().StringVarP(&forkOrg, "org", "o", "", "Organization to fork into")
+	repoForkCmd.Flags().BoolVar(&forkClone, "clone", false, "Clone the fork and add an upstream remote")
+
+	repoViewCmd.Flags().BoolVarP(&viewWeb, "web", "w", false, "Open the repository in the browser")
+	repoViewCmd.Flags().StringVarP(&viewBranch, "branch", "b", "", "Branch or ref to show the README of")
+
+	repoWatchCmd.Flags().StringVarP(&watchLevel, "level", "l", "", "Watch level: all, releases or ignore")
+
+	repoWorkflowCmd.Flags().IntVarP(&workflowSize, "size", "s", 30, "Number of workflows to list")
+
+	repoWorkflowRunCmd.Flags().StringVarP(&repoFlag, "repo", "R", "", "Repository in the <owner/repo> format")
+	repoWorkflowRunCmd.Flags().StringVarP(&workflowRef, "ref", "r", "", "Branch or tag to run the workflow on")
+	repoWorkflowRunCmd.Flags().StringArrayVarP(&workflowFields, "field", "f", nil, "Workflow input in the key=value format")
+
+	repoWorkflowEnableCmd.Flags().StringVarP(&repoFlag, "repo", "R", "", "Repository in the <owner/repo> format")
+	repoWorkflowEnableCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the API calls without performing them")
+
+	repoWorkflowDisableCmd.Flags().StringVarP(&repoFlag, "repo", "R", "", "Repository in the <owner/repo> format")
+	repoWorkflowDisableCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the API calls without performing them")
 }
@@ -3,9 +3,12 @@ package cmd
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/carolinafsilva/go-github-cli/api"
+	"github.com/google/go-github/v55/github"
 )
 
 const (
@@ -22,7 +25,7 @@ func TestRepoCmdPrintHelpMenu(t *testing.T) {
 
 	args := []string{"--help", "-h", "help", "", "invalidcmd"}
 
-	expectedOutput := "The repo command in GG allows you to interact with GitHub repositories. This command provides subcommands for listing repositories and their workflows.\n\nUsage:\n  gg repo [command]\n\nAvailable Commands:\n  list        List a user's repositories\n  workflow    List a repository's workflows\n\nFlags:\n  -h, --help   help for repo\n\nUse \"gg repo [command] --help\" for more information about a command.\n"
+	expectedOutput := "The repo command in GG allows you to interact with GitHub repositories. This command provides subcommands for listing repositories and their workflows.\n\nUsage:\n  gg repo [command]\n\nAvailable Commands:\n  archive     Archive a repository\n  bulk        Apply a change to many repositories\n  clone       Clone a repository\n  create      Create a repository\n  delete      Delete a repository\n  edit        Edit the settings of a repository\n  fork        Fork a repository\n  list        List the repositories of a user, organization or team\n  protection  Inspect and apply branch protection and rulesets\n  rename      Rename a repository\n  star        Star a repository\n  unarchive   Unarchive a repository\n  unstar      Unstar a repository\n  view        Show a repository and its README\n  watch       Show or change how you watch a repository\n  workflow    List a repository's workflows\n\nFlags:\n  -h, --help   help for repo\n\nUse \"gg repo [command] --help\" for more information about a command.\n"
 
 	for _, arg := range args {
 		cmd.SetArgs([]string{"repo", arg})
@@ -88,6 +91,25 @@ func TestRepoListCmdWithTwoArgs(t *testing.T) {
 	}
 }
 
+func TestRepoListCmdWithUsernameAndOrg(t *testing.T) {
+	cmd := rootCmd
+
+	cmd.SetArgs([]string{"repo", "list", "carolinafsilva", "--org", "acme"})
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal(expectedErrorGotNil)
+	}
+
+	expectedErr := "--org and --team cannot be combined with a username"
+	if err.Error() != expectedErr {
+		t.Errorf(expectedDifferentError, expectedErr, err.Error())
+	}
+
+	t.Cleanup(func() {
+		repoOrg = ""
+	})
+}
+
 func TestRepoListCmdWithInvalidUsername(t *testing.T) {
 	cmd := rootCmd
 
@@ -125,16 +147,12 @@ func TestRepoListCmdWithValidUsername(t *testing.T) {
 	}
 
 	expectedMsg := fmt.Sprintln("Owned Repositories:")
-	repos, _ := api.GetOwnedRepos("carolinafsilva", 30)
-	for _, repo := range repos {
-		expectedMsg += fmt.Sprintln(*repo.Name)
-	}
+	repos, _ := api.GetOwnedRepos("carolinafsilva", 30, api.RepoFilter{})
+	expectedMsg += renderRepos(repos, time.Now())
 
 	expectedMsg += fmt.Sprintln("Followed Repositories:")
-	repos, _ = api.GetFollowedRepos("carolinafsilva", 30)
-	for _, repo := range repos {
-		expectedMsg += fmt.Sprintln(*repo.Name)
-	}
+	repos, _ = api.GetFollowedRepos("carolinafsilva", 30, api.RepoFilter{})
+	expectedMsg += renderRepos(repos, time.Now())
 
 	if output.String() != expectedMsg {
 		t.Errorf(expectedDifferentError, expectedMsg, output.String())
@@ -159,10 +177,8 @@ func TestRepoListCmdWithValidUsernameAndOwnedFlag(t *testing.T) {
 	}
 
 	expectedMsg := fmt.Sprintln("Owned Repositories:")
-	repos, _ := api.GetOwnedRepos("carolinafsilva", 30)
-	for _, repo := range repos {
-		expectedMsg += fmt.Sprintln(*repo.Name)
-	}
+	repos, _ := api.GetOwnedRepos("carolinafsilva", 30, api.RepoFilter{})
+	expectedMsg += renderRepos(repos, time.Now())
 
 	if output.String() != expectedMsg {
 		t.Errorf(expectedDifferentError, expectedMsg, output.String())
@@ -171,9 +187,7 @@ func TestRepoListCmdWithValidUsernameAndOwnedFlag(t *testing.T) {
 	t.Cleanup(func() {
 		cmd.SetOut(nil)
 		repoListCmd.ResetFlags()
-		repoListCmd.Flags().BoolVar(&owned, "owned", false, "List only owned repos")
-		repoListCmd.Flags().BoolVar(&followed, "followed", false, "List only followed repos")
-		repoListCmd.MarkFlagsMutuallyExclusive("owned", "followed")
+		addRepoListFlags()
 	})
 }
 
@@ -190,10 +204,8 @@ func TestRepoListCmdWithValidUsernameAndFollowedFlag(t *testing.T) {
 	}
 
 	expectedMsg := fmt.Sprintln("Followed Repositories:")
-	repos, _ := api.GetFollowedRepos("carolinafsilva", 30)
-	for _, repo := range repos {
-		expectedMsg += fmt.Sprintln(*repo.Name)
-	}
+	repos, _ := api.GetFollowedRepos("carolinafsilva", 30, api.RepoFilter{})
+	expectedMsg += renderRepos(repos, time.Now())
 
 	if output.String() != expectedMsg {
 		t.Errorf(expectedDifferentError, expectedMsg, output.String())
@@ -202,9 +214,7 @@ func TestRepoListCmdWithValidUsernameAndFollowedFlag(t *testing.T) {
 	t.Cleanup(func() {
 		cmd.SetOut(nil)
 		repoListCmd.ResetFlags()
-		repoListCmd.Flags().BoolVar(&owned, "owned", false, "List only owned repos")
-		repoListCmd.Flags().BoolVar(&followed, "followed", false, "List only followed repos")
-		repoListCmd.MarkFlagsMutuallyExclusive("owned", "followed")
+		addRepoListFlags()
 	})
 }
 
@@ -274,18 +284,212 @@ func TestRepoWorkflowCmdWithValidRepoPath(t *testing.T) {
 		t.Errorf(expectedNoError, err)
 	}
 
-	workflows, _ := api.ListRepoWorkflows("aleph-two/flowcar.pt")
+	workflows, _ := api.ListRepoWorkflows("aleph-two/flowcar.pt", 30)
 
-	var expectedMsg string
 	for _, workflow := range workflows.Workflows {
-		expectedMsg += fmt.Sprintf("%s\n", workflow.GetName())
+		expectedMsg := fmt.Sprintf("%s (%d)\n  Path:     %s\n", workflow.GetName(), workflow.GetID(), workflow.GetPath())
+		if !strings.Contains(output.String(), expectedMsg) {
+			t.Errorf(expectedDifferentError, expectedMsg, output.String())
+		}
 	}
 
+	t.Cleanup(func() {
+		cmd.SetOut(nil)
+	})
+}
+
+func TestRepoWorkflowRunCmdWithNoArgs(t *testing.T) {
+	cmd := rootCmd
+
+	cmd.SetArgs([]string{"repo", "workflow", "run"})
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal(expectedErrorGotNil)
+	}
+
+	expectedErr := "accepts 1 arg(s), received 0"
+	if err.Error() != expectedErr {
+		t.Errorf(expectedDifferentError, expectedErr, err.Error())
+	}
+}
+
+func TestRepoWorkflowEnableCmdWithNoArgs(t *testing.T) {
+	cmd := rootCmd
+
+	cmd.SetArgs([]string{"repo", "workflow", "enable"})
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal(expectedErrorGotNil)
+	}
+
+	expectedErr := "accepts 1 arg(s), received 0"
+	if err.Error() != expectedErr {
+		t.Errorf(expectedDifferentError, expectedErr, err.Error())
+	}
+}
+
+func TestRenderRepos(t *testing.T) {
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	repos := []*github.Repository{{
+		FullName:        github.String("acme/api"),
+		Visibility:      github.String("public"),
+		Fork:            github.Bool(true),
+		Archived:        github.Bool(true),
+		Language:        github.String("Go"),
+		StargazersCount: github.Int(42),
+		ForksCount:      github.Int(7),
+		OpenIssuesCount: github.Int(3),
+		DefaultBranch:   github.String("main"),
+		PushedAt:        &github.Timestamp{Time: now.Add(-3 * 24 * time.Hour)},
+	}}
+
+	table := renderRepos(repos, now)
+
+	expected := fmt.Sprintf("%-40s %-10s %-22s %-12s %6s %6s %6s %-15s %s\n", "acme/api", "public", "fork,archived", "Go", "42", "7", "3", "main", "3d ago")
+	if !strings.HasSuffix(table, expected) {
+		t.Errorf(expectedDifferentError, expected, table)
+	}
+}
+
+func TestRenderStarredRepos(t *testing.T) {
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	starred := []*github.StarredRepository{{
+		StarredAt: &github.Timestamp{Time: now.Add(-2 * time.Hour)},
+		Repository: &github.Repository{
+			FullName:      github.String("acme/api"),
+			Visibility:    github.String("public"),
+			DefaultBranch: github.String("main"),
+		},
+	}}
+
+	table := renderStarredRepos(starred, now)
+
+	expected := fmt.Sprintf("%-40s %-10s %-22s %-12s %6s %6s %6s %-15s %-10s %s\n", "acme/api", "public", "-", "-", "0", "0", "0", "main", "-", "2h ago")
+	if !strings.HasSuffix(table, expected) {
+		t.Errorf(expectedDifferentError, expected, table)
+	}
+}
+
+func TestRenderRepoView(t *testing.T) {
+	details := &api.RepoDetails{
+		Repo: &github.Repository{
+			FullName:      github.String("acme/api"),
+			Description:   github.String("The acme API"),
+			Topics:        []string{"go", "cli"},
+			License:       &github.License{Name: github.String("MIT License")},
+			DefaultBranch: github.String("main"),
+		},
+		Languages:     []api.LanguageShare{{Name: "Go", Percent: 92.5}, {Name: "Shell", Percent: 7.5}},
+		Contributors:  4,
+		LatestRelease: &github.RepositoryRelease{TagName: github.String("v1.2.0"), PublishedAt: &github.Timestamp{Time: time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC)}},
+		Readme:        "# API\n",
+	}
+
+	view := renderRepoView(details)
+
+	for _, expected := range []string{
+		"acme/api\nThe acme API\n",
+		"  Topics:          go, cli\n",
+		"  License:         MIT License\n",
+		"  Homepage:        -\n",
+		"  Languages:       Go 92.5%, Shell 7.5%\n",
+		"  Contributors:    4\n",
+		"  Latest release:  v1.2.0 (2023-09-01)\n\nAPI\n",
+	} {
+		if !strings.Contains(view, expected) {
+			t.Errorf(expectedDifferentError, expected, view)
+		}
+	}
+}
+
+func TestRepoCloneCmdWithNoArgs(t *testing.T) {
+	cmd := rootCmd
+
+	cmd.SetArgs([]string{"repo", "clone"})
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal(expectedErrorGotNil)
+	}
+
+	expectedErr := "accepts between 1 and 2 arg(s), received 0"
+	if err.Error() != expectedErr {
+		t.Errorf(expectedDifferentError, expectedErr, err.Error())
+	}
+}
+
+func TestRepoDeleteCmdWithDryRun(t *testing.T) {
+	cmd := rootCmd
+
+	var output bytes.Buffer
+	cmd.SetOut(&output)
+
+	cmd.SetArgs([]string{"repo", "delete", "acme/api", "--dry-run"})
+	err := cmd.Execute()
+	if err != nil {
+		t.Errorf(expectedNoError, err)
+	}
+
+	expectedMsg := "DELETE /repos/acme/api\n"
 	if output.String() != expectedMsg {
 		t.Errorf(expectedDifferentError, expectedMsg, output.String())
 	}
 
 	t.Cleanup(func() {
 		cmd.SetOut(nil)
+		dryRun = false
+	})
+}
+
+func TestConfirmRepoName(t *testing.T) {
+	answers := map[string]bool{"acme/api\n": true, "api\n": false, "": false}
+
+	for answer, expected := range answers {
+		var output bytes.Buffer
+		repoDeleteCmd.SetOut(&output)
+		repoDeleteCmd.SetIn(strings.NewReader(answer))
+
+		if confirmed := confirmRepoName(repoDeleteCmd, "acme/api"); confirmed != expected {
+			t.Errorf("expected answer %q to give %t, got %t", answer, expected, confirmed)
+		}
+	}
+
+	t.Cleanup(func() {
+		repoDeleteCmd.SetOut(nil)
+		repoDeleteCmd.SetIn(nil)
 	})
 }
+
+func TestRepoWatchCmdWithTwoArgs(t *testing.T) {
+	cmd := rootCmd
+
+	cmd.SetArgs([]string{"repo", "watch", "acme/api", "acme/web"})
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal(expectedErrorGotNil)
+	}
+
+	expectedErr := "accepts at most 1 arg(s), received 2"
+	if err.Error() != expectedErr {
+		t.Errorf(expectedDifferentError, expectedErr, err.Error())
+	}
+}
+
+func TestParseFields(t *testing.T) {
+	values, err := parseFields([]string{"environment=staging", "message=a=b"})
+	if err != nil {
+		t.Errorf(expectedNoError, err)
+	}
+
+	if values["environment"] != "staging" || values["message"] != "a=b" {
+		t.Errorf(expectedDifferentError, "environment=staging message=a=b", values)
+	}
+
+	_, err = parseFields([]string{"environment"})
+
+	expectedErr := "invalid field 'environment', use the key=value format"
+	if err == nil {
+		t.Error(expectedErrorGotNil)
+	} else if err.Error() != expectedErr {
+		t.Errorf(expectedDifferentError, expectedErr, err.Error())
+	}
+}
@@ -0,0 +1,484 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/carolinafsilva/go-github-cli/api"
+	"github.com/fatih/color"
+	"github.com/google/go-github/v55/github"
+	"github.com/spf13/cobra"
+)
+
+var (
+	runFilter     api.RunFilter
+	runSize       int
+	runJob        string
+	logsDir       string
+	downloadDir   string
+	artifactNames []string
+	rerunFailed   bool
+	rerunDebug    bool
+)
+
+var errRunFailed = errors.New("the workflow run did not succeed")
+
+var runCmd = &cobra.Command{
+	Use:   "run <command> [flags]",
+	Short: "Get information about GitHub Actions workflow runs",
+	Long:  `The run command in GG allows you to inspect GitHub Actions workflow runs. This command provides subcommands for listing runs, viewing their jobs, reading their logs and downloading their artifacts.`,
+}
+
+var runListCmd = &cobra.Command{
+	Use:   "list [flags]",
+	Short: "List a repository's workflow runs",
+	Long:  `The list subcommand within the run command lists the most recent workflow runs of a repository. The runs can be filtered by workflow, branch, triggering event, actor and status.`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		repoPath, err := resolveRepoPath()
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		runs, err := api.ListRuns(repoPath, runFilter, runSize)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		if len(runs) == 0 {
+			cmd.Println("No workflow runs found.")
+			return
+		}
+
+		for _, run := range runs {
+			state := runState(run)
+			magenta.Fprintf(cmd.OutOrStdout(), "%-12d ", run.GetID())
+			conclusionColor(state).Fprintf(cmd.OutOrStdout(), "%-12s ", state)
+			fg.Fprintf(cmd.OutOrStdout(), "%-20s %-20s %-18s %4s  %s\n", run.GetName(), run.GetHeadBranch(), run.GetEvent(), formatAge(time.Since(run.GetCreatedAt().Time)), run.GetDisplayTitle())
+		}
+	},
+}
+
+var runViewCmd = &cobra.Command{
+	Use:   "view <run-id> [flags]",
+	Short: "Show the jobs and steps of a workflow run",
+	Long:  `The view subcommand within the run command shows a workflow run's details along with each of its jobs and steps and how long they took.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		repoPath, id, err := resolveRun(args[0])
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		run, err := api.GetRun(repoPath, id)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		jobs, err := api.ListRunJobs(repoPath, id)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		fg.Fprint(cmd.OutOrStdout(), renderRun(run, jobs))
+	},
+}
+
+var runLogsCmd = &cobra.Command{
+	Use:   "logs <run-id> [flags]",
+	Short: "Show the logs of a workflow run",
+	Long: `The logs subcommand within the run command prints the logs of every job of a workflow run.
+
+--job: Stream the log of a single job, given by its name or ID.
+--dir: Extract the log archive into a directory, with one file per job and per step, instead of printing it.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		repoPath, id, err := resolveRun(args[0])
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		if runJob != "" {
+			jobs, err := api.ListRunJobs(repoPath, id)
+			if err != nil {
+				cmd.Println(err)
+				return
+			}
+
+			job, err := findJob(jobs, runJob)
+			if err != nil {
+				cmd.Println(err)
+				return
+			}
+
+			logs, err := api.GetJobLogs(repoPath, job.GetID())
+			if err != nil {
+				cmd.Println(err)
+				return
+			}
+			defer logs.Close()
+
+			if _, err := io.Copy(cmd.OutOrStdout(), logs); err != nil {
+				cmd.Println(err)
+			}
+			return
+		}
+
+		archive, err := api.GetRunLogs(repoPath, id)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		if logsDir != "" {
+			if err := api.ExtractZip(archive, logsDir); err != nil {
+				cmd.Println(err)
+				return
+			}
+
+			cmd.Printf("Logs extracted to %s\n", logsDir)
+			return
+		}
+
+		logs, err := api.JobLogs(archive)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		for _, log := range logs {
+			magenta.Fprintf(cmd.OutOrStdout(), "==> %s <==\n", log.Name)
+			fg.Fprintf(cmd.OutOrStdout(), "%s\n", log.Content)
+		}
+	},
+}
+
+var runDownloadCmd = &cobra.Command{
+	Use:   "download <run-id> [flags]",
+	Short: "Download the artifacts of a workflow run",
+	Long: `The download subcommand within the run command downloads the artifacts of a workflow run and extracts each of them into its own directory.
+
+--dir: Directory to extract the artifacts into, defaults to the current directory.
+--name: Only download the artifacts with the given names.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		repoPath, id, err := resolveRun(args[0])
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		artifacts, err := api.ListRunArtifacts(repoPath, id)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		downloaded := 0
+		for _, artifact := range artifacts {
+			if !selectedArtifact(artifact, artifactNames) {
+				continue
+			}
+
+			if artifact.GetExpired() {
+				cmd.Printf("Skipping %s, the artifact has expired\n", artifact.GetName())
+				continue
+			}
+
+			archive, err := api.DownloadArtifact(repoPath, artifact)
+			if err != nil {
+				cmd.Println(err)
+				return
+			}
+
+			target := filepath.Join(downloadDir, artifact.GetName())
+			if err := api.ExtractZip(archive, target); err != nil {
+				cmd.Println(err)
+				return
+			}
+
+			cmd.Printf("Downloaded %s to %s\n", artifact.GetName(), target)
+			downloaded++
+		}
+
+		if downloaded == 0 {
+			cmd.Println("No artifacts to download.")
+		}
+	},
+}
+
+var runWatchCmd = &cobra.Command{
+	Use:          "watch <run-id> [flags]",
+	Short:        "Follow a workflow run until it finishes",
+	Long:         `The watch subcommand within the run command refreshes a workflow run's jobs and steps in place until the run completes. The command exits with a non-zero status when the run does not conclude successfully.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		repoPath, id, err := resolveRun(args[0])
+		if err != nil {
+			return err
+		}
+
+		var run *github.WorkflowRun
+		err = watch(cmd.OutOrStdout(), func() (string, string, bool, error) {
+			run, err = api.GetRun(repoPath, id)
+			if err != nil {
+				return "", "", false, err
+			}
+
+			jobs, err := api.ListRunJobs(repoPath, id)
+			if err != nil {
+				return "", "", false, err
+			}
+
+			return renderRun(run, jobs), runWatchState(run, jobs), run.GetStatus() == "completed", nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if !runSucceeded(run) {
+			return errRunFailed
+		}
+
+		return nil
+	},
+}
+
+var runRerunCmd = &cobra.Command{
+	Use:   "rerun <run-id> [flags]",
+	Short: "Re-run a workflow run",
+	Long: `The rerun subcommand within the run command re-runs every job of a completed workflow run.
+
+--failed: Only re-run the failed jobs and the jobs that depend on them.
+--debug: Enable runner and step debug logging for the new attempt.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		repoPath, id, err := resolveRun(args[0])
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		if err := api.RerunRun(repoPath, id, rerunFailed, rerunDebug); err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		if rerunFailed {
+			cmd.Printf("Requested a re-run of the failed jobs of run %d\n", id)
+		} else {
+			cmd.Printf("Requested a re-run of run %d\n", id)
+		}
+	},
+}
+
+var runCancelCmd = &cobra.Command{
+	Use:   "cancel <run-id> [flags]",
+	Short: "Cancel a workflow run",
+	Long:  `The cancel subcommand within the run command cancels a queued or in progress workflow run.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		repoPath, id, err := resolveRun(args[0])
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		if err := api.CancelRun(repoPath, id); err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		cmd.Printf("Requested the cancellation of run %d\n", id)
+	},
+}
+
+func resolveRun(arg string) (string, int64, error) {
+	id, err := parseRunID(arg)
+	if err != nil {
+		return "", 0, err
+	}
+
+	repoPath, err := resolveRepoPath()
+	if err != nil {
+		return "", 0, err
+	}
+
+	return repoPath, id, nil
+}
+
+func parseRunID(arg string) (int64, error) {
+	id, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid run ID '%s'", arg)
+	}
+
+	return id, nil
+}
+
+func findJob(jobs []*github.WorkflowJob, selector string) (*github.WorkflowJob, error) {
+	for _, job := range jobs {
+		if strconv.FormatInt(job.GetID(), 10) == selector || strings.EqualFold(job.GetName(), selector) {
+			return job, nil
+		}
+	}
+
+	return nil, fmt.Errorf("could not find job '%s' in the workflow run", selector)
+}
+
+func selectedArtifact(artifact *github.Artifact, names []string) bool {
+	if len(names) == 0 {
+		return true
+	}
+
+	for _, name := range names {
+		if artifact.GetName() == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func runState(run *github.WorkflowRun) string {
+	return jobState(run.GetStatus(), run.GetConclusion())
+}
+
+func runSucceeded(run *github.WorkflowRun) bool {
+	switch run.GetConclusion() {
+	case "success", "skipped", "neutral":
+		return true
+	}
+
+	return false
+}
+
+func jobState(status string, conclusion string) string {
+	if status != "completed" {
+		return status
+	}
+
+	return conclusion
+}
+
+func conclusionColor(state string) *color.Color {
+	switch state {
+	case "success":
+		return color.New(color.Bold, color.FgGreen)
+	case "skipped", "neutral":
+		return color.New(color.Bold)
+	case "queued", "in_progress", "waiting", "requested", "pending":
+		return color.New(color.Bold, color.FgYellow)
+	}
+
+	return color.New(color.Bold, color.FgRed)
+}
+
+func elapsed(start *github.Timestamp, end *github.Timestamp) time.Duration {
+	if start.GetTime() == nil || start.IsZero() {
+		return 0
+	}
+
+	if end.GetTime() == nil || end.IsZero() {
+		return time.Since(start.Time).Round(time.Second)
+	}
+
+	return end.Sub(start.Time).Round(time.Second)
+}
+
+// runWatchState lists the state of the run and of each job and step, leaving
+// out the elapsed times that change on every poll.
+func runWatchState(run *github.WorkflowRun, jobs []*github.WorkflowJob) string {
+	var state strings.Builder
+	state.WriteString(fmt.Sprintf("%s\n", runState(run)))
+
+	for _, job := range jobs {
+		state.WriteString(fmt.Sprintf("%s %s\n", job.GetName(), jobState(job.GetStatus(), job.GetConclusion())))
+		for _, step := range job.Steps {
+			state.WriteString(fmt.Sprintf("  %s %s\n", step.GetName(), jobState(step.GetStatus(), step.GetConclusion())))
+		}
+	}
+
+	return state.String()
+}
+
+func renderRun(run *github.WorkflowRun, jobs []*github.WorkflowJob) string {
+	var frame strings.Builder
+	state := runState(run)
+
+	var end *github.Timestamp
+	if run.GetStatus() == "completed" {
+		end = run.UpdatedAt
+	}
+
+	frame.WriteString(magenta.Sprintf("%s #%d", run.GetName(), run.GetRunNumber()))
+	frame.WriteString(fg.Sprintf(" %s\n", run.GetDisplayTitle()))
+	frame.WriteString(conclusionColor(state).Sprintf("%s", state))
+	frame.WriteString(fg.Sprintf("  %s  %s by %s  %s\n", run.GetHeadBranch(), run.GetEvent(), run.GetActor().GetLogin(), elapsed(run.RunStartedAt, end)))
+	frame.WriteString(fg.Sprintf("%s\n", run.GetHTMLURL()))
+
+	for _, job := range jobs {
+		state := jobState(job.GetStatus(), job.GetConclusion())
+		frame.WriteString("\n")
+		frame.WriteString(conclusionColor(state).Sprintf("%-12s ", state))
+		frame.WriteString(fg.Sprintf("%s  %s\n", job.GetName(), elapsed(job.StartedAt, job.CompletedAt)))
+
+		for _, step := range job.Steps {
+			state := jobState(step.GetStatus(), step.GetConclusion())
+			frame.WriteString(conclusionColor(state).Sprintf("  %-12s ", state))
+			frame.WriteString(fg.Sprintf("%s  %s\n", step.GetName(), elapsed(step.StartedAt, step.CompletedAt)))
+		}
+	}
+
+	return frame.String()
+}
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+	runCmd.AddCommand(runListCmd)
+	runCmd.AddCommand(runViewCmd)
+	runCmd.AddCommand(runLogsCmd)
+	runCmd.AddCommand(runDownloadCmd)
+	runCmd.AddCommand(runWatchCmd)
+	runCmd.AddCommand(runRerunCmd)
+	runCmd.AddCommand(runCancelCmd)
+
+	runListCmd.Flags().StringVarP(&repoFlag, "repo", "R", "", "Repository in the <owner/repo> format")
+	runListCmd.Flags().IntVarP(&runSize, "size", "S", 20, "Number of runs to list")
+	runListCmd.Flags().StringVarP(&runFilter.Workflow, "workflow", "w", "", "Only list runs of a workflow, by name, ID or file name")
+	runListCmd.Flags().StringVarP(&runFilter.Branch, "branch", "b", "", "Only list runs on a branch")
+	runListCmd.Flags().StringVarP(&runFilter.Event, "event", "e", "", "Only list runs triggered by an event")
+	runListCmd.Flags().StringVarP(&runFilter.Actor, "actor", "a", "", "Only list runs triggered by a user")
+	runListCmd.Flags().StringVarP(&runFilter.Status, "status", "s", "", "Only list runs with a status or conclusion")
+
+	runViewCmd.Flags().StringVarP(&repoFlag, "repo", "R", "", "Repository in the <owner/repo> format")
+
+	runLogsCmd.Flags().StringVarP(&repoFlag, "repo", "R", "", "Repository in the <owner/repo> format")
+	runLogsCmd.Flags().StringVarP(&runJob, "job", "j", "", "Only show the log of a job, by name or ID")
+	runLogsCmd.Flags().StringVarP(&logsDir, "dir", "d", "", "Extract the logs into a directory")
+	runLogsCmd.MarkFlagsMutuallyExclusive("job", "dir")
+
+	runDownloadCmd.Flags().StringVarP(&repoFlag, "repo", "R", "", "Repository in the <owner/repo> format")
+	runDownloadCmd.Flags().StringVarP(&downloadDir, "dir", "d", ".", "Directory to download the artifacts into")
+	runDownloadCmd.Flags().StringSliceVarP(&artifactNames, "name", "n", nil, "Only download artifacts with these names")
+
+	runWatchCmd.Flags().StringVarP(&repoFlag, "repo", "R", "", "Repository in the <owner/repo> format")
+
+	runRerunCmd.Flags().StringVarP(&repoFlag, "repo", "R", "", "Repository in the <owner/repo> format")
+	runRerunCmd.Flags().BoolVar(&rerunFailed, "failed", false, "Only re-run the failed jobs")
+	runRerunCmd.Flags().BoolVar(&rerunDebug, "debug", false, "Enable debug logging for the re-run")
+
+	runCancelCmd.Flags().StringVarP(&repoFlag, "repo", "R", "", "Repository in the <owner/repo> format")
+}
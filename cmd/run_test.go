@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+)
+
+func TestRunCmdPrintHelpMenu(t *testing.T) {
+	cmd := rootCmd
+
+	var output bytes.Buffer
+	cmd.SetOut(&output)
+
+	args := []string{"--help", "-h", "help", "", "invalidcmd"}
+
+	expectedOutput := "The run command in GG allows you to inspect GitHub Actions workflow runs. This command provides subcommands for listing runs, viewing their jobs, reading their logs and downloading their artifacts.\n\nUsage:\n  gg run [command]\n\nAvailable Commands:\n  cancel      Cancel a workflow run\n  download    Download the artifacts of a workflow run\n  list        List a repository's workflow runs\n  logs        Show the logs of a workflow run\n  rerun       Re-run a workflow run\n  view        Show the jobs and steps of a workflow run\n  watch       Follow a workflow run until it finishes\n\nFlags:\n  -h, --help   help for run\n\nUse \"gg run [command] --help\" for more information about a command.\n"
+
+	for _, arg := range args {
+		cmd.SetArgs([]string{"run", arg})
+		err := cmd.Execute()
+		if err != nil {
+			t.Errorf(expectedNoError, err)
+		}
+
+		if output.String() != expectedOutput {
+			t.Errorf(expectedDifferentError, expectedOutput, output.String())
+		}
+
+		output.Reset()
+	}
+
+	t.Cleanup(func() {
+		cmd.SetOut(nil)
+	})
+}
+
+func TestRunViewCmdWithNoArgs(t *testing.T) {
+	cmd := rootCmd
+
+	cmd.SetArgs([]string{"run", "view"})
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal(expectedErrorGotNil)
+	}
+
+	expectedErr := "accepts 1 arg(s), received 0"
+	if err.Error() != expectedErr {
+		t.Errorf(expectedDifferentError, expectedErr, err.Error())
+	}
+}
+
+func TestRunLogsCmdWithInvalidRunID(t *testing.T) {
+	cmd := rootCmd
+
+	var output bytes.Buffer
+	cmd.SetOut(&output)
+
+	cmd.SetArgs([]string{"run", "logs", "latest", "--repo", "carolinafsilva/go-github-cli"})
+
+	err := cmd.Execute()
+	if err != nil {
+		t.Errorf(expectedNoError, err)
+	}
+
+	expectedMsg := "invalid run ID 'latest'\n"
+	if output.String() != expectedMsg {
+		t.Errorf(expectedDifferentError, expectedMsg, output.String())
+	}
+
+	t.Cleanup(func() {
+		cmd.SetOut(nil)
+		repoFlag = ""
+	})
+}
+
+func TestRunCancelCmdWithNoArgs(t *testing.T) {
+	cmd := rootCmd
+
+	cmd.SetArgs([]string{"run", "cancel"})
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal(expectedErrorGotNil)
+	}
+
+	expectedErr := "accepts 1 arg(s), received 0"
+	if err.Error() != expectedErr {
+		t.Errorf(expectedDifferentError, expectedErr, err.Error())
+	}
+}
+
+func TestRunRerunCmdWithInvalidRunID(t *testing.T) {
+	cmd := rootCmd
+
+	var output bytes.Buffer
+	cmd.SetOut(&output)
+
+	cmd.SetArgs([]string{"run", "rerun", "abc", "--failed", "--repo", "carolinafsilva/go-github-cli"})
+
+	err := cmd.Execute()
+	if err != nil {
+		t.Errorf(expectedNoError, err)
+	}
+
+	expectedMsg := "invalid run ID 'abc'\n"
+	if output.String() != expectedMsg {
+		t.Errorf(expectedDifferentError, expectedMsg, output.String())
+	}
+
+	t.Cleanup(func() {
+		cmd.SetOut(nil)
+		repoFlag = ""
+		rerunFailed = false
+	})
+}
+
+func TestRunSucceeded(t *testing.T) {
+	conclusions := map[string]bool{
+		"success":   true,
+		"skipped":   true,
+		"neutral":   true,
+		"failure":   false,
+		"cancelled": false,
+		"timed_out": false,
+		"":          false,
+	}
+
+	for conclusion, expected := range conclusions {
+		run := &github.WorkflowRun{Conclusion: github.String(conclusion)}
+		if runSucceeded(run) != expected {
+			t.Errorf("expected runSucceeded to be %t for conclusion '%s'", expected, conclusion)
+		}
+	}
+}
+
+func TestFindJob(t *testing.T) {
+	jobs := []*github.WorkflowJob{
+		{ID: github.Int64(11), Name: github.String("build")},
+		{ID: github.Int64(12), Name: github.String("test")},
+	}
+
+	for _, selector := range []string{"12", "Test"} {
+		job, err := findJob(jobs, selector)
+		if err != nil {
+			t.Errorf(expectedNoError, err)
+		} else if job.GetID() != 12 {
+			t.Errorf(expectedDifferentError, 12, job.GetID())
+		}
+	}
+
+	_, err := findJob(jobs, "lint")
+
+	expectedErr := "could not find job 'lint' in the workflow run"
+	if err == nil {
+		t.Error(expectedErrorGotNil)
+	} else if err.Error() != expectedErr {
+		t.Errorf(expectedDifferentError, expectedErr, err.Error())
+	}
+}
+
+func TestRenderRun(t *testing.T) {
+	start := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	run := &github.WorkflowRun{
+		Name:         github.String("CI"),
+		RunNumber:    github.Int(7),
+		Status:       github.String("completed"),
+		Conclusion:   github.String("failure"),
+		RunStartedAt: &github.Timestamp{Time: start},
+		UpdatedAt:    &github.Timestamp{Time: start.Add(2 * time.Minute)},
+	}
+	jobs := []*github.WorkflowJob{{
+		Name:        github.String("test"),
+		Status:      github.String("completed"),
+		Conclusion:  github.String("failure"),
+		StartedAt:   &github.Timestamp{Time: start},
+		CompletedAt: &github.Timestamp{Time: start.Add(90 * time.Second)},
+		Steps: []*github.TaskStep{{
+			Name:        github.String("go test"),
+			Status:      github.String("completed"),
+			Conclusion:  github.String("failure"),
+			StartedAt:   &github.Timestamp{Time: start},
+			CompletedAt: &github.Timestamp{Time: start.Add(80 * time.Second)},
+		}},
+	}}
+
+	frame := renderRun(run, jobs)
+
+	for _, expected := range []string{"CI #7", "failure", "2m0s", "test  1m30s", "go test  1m20s"} {
+		if !strings.Contains(frame, expected) {
+			t.Errorf(expectedDifferentError, expected, frame)
+		}
+	}
+}
+
+func TestRunWatchStateIgnoresElapsedTime(t *testing.T) {
+	run := &github.WorkflowRun{
+		Status:       github.String("in_progress"),
+		RunStartedAt: &github.Timestamp{Time: time.Now().Add(-time.Minute)},
+	}
+	jobs := []*github.WorkflowJob{{
+		Name:      github.String("test"),
+		Status:    github.String("in_progress"),
+		StartedAt: &github.Timestamp{Time: time.Now().Add(-time.Minute)},
+	}}
+
+	state := runWatchState(run, jobs)
+	expectedState := "in_progress\ntest in_progress\n"
+	if state != expectedState {
+		t.Errorf(expectedDifferentError, expectedState, state)
+	}
+
+	jobs[0].StartedAt = &github.Timestamp{Time: time.Now().Add(-time.Hour)}
+	if runWatchState(run, jobs) != state {
+		t.Errorf(expectedDifferentError, state, runWatchState(run, jobs))
+	}
+}
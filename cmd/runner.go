@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/carolinafsilva/go-github-cli/api"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var runnerOrg string
+
+var runnerCmd = &cobra.Command{
+	Use:   "runner <command> [flags]",
+	Short: "Manage self-hosted GitHub Actions runners",
+	Long: `The runner command in GG allows you to inspect and remove the self-hosted runners of a repository or an organization, and to create tokens to register new ones.
+
+--repo: Repository in the <owner/repo> format, defaults to the origin remote of the current directory.
+--org: Use the runners of an organization instead of a repository.`,
+}
+
+var runnerListCmd = &cobra.Command{
+	Use:   "list [flags]",
+	Short: "List self-hosted runners",
+	Long:  `The list subcommand within the runner command lists the self-hosted runners of a scope with their status, OS, group and labels.`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		scope, err := resolveRunnerScope()
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		runners, err := api.ListRunners(scope)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		if len(runners) == 0 {
+			cmd.Println("No self-hosted runners found.")
+			return
+		}
+
+		for _, runner := range runners {
+			status := runnerStatus(runner)
+			magenta.Fprintf(cmd.OutOrStdout(), "%-30s ", runner.Runner.GetName())
+			runnerStatusColor(status).Fprintf(cmd.OutOrStdout(), "%-8s ", status)
+			fg.Fprintf(cmd.OutOrStdout(), "%-8s %-20s %s\n", runner.Runner.GetOS(), runner.Group, strings.Join(runner.Labels(), ", "))
+		}
+	},
+}
+
+var runnerViewCmd = &cobra.Command{
+	Use:   "view <runner> [flags]",
+	Short: "Show a self-hosted runner",
+	Long:  `The view subcommand within the runner command shows the details of a self-hosted runner, given by its name or ID.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		scope, err := resolveRunnerScope()
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		runner, err := api.GetRunner(scope, args[0])
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		status := runnerStatus(runner)
+		magenta.Fprintf(cmd.OutOrStdout(), "%s", runner.Runner.GetName())
+		fg.Fprintf(cmd.OutOrStdout(), " (%d)\n", runner.Runner.GetID())
+		runnerStatusColor(status).Fprintf(cmd.OutOrStdout(), "  Status: %s\n", status)
+		fg.Fprintf(cmd.OutOrStdout(), "  OS:     %s\n", runner.Runner.GetOS())
+		fg.Fprintf(cmd.OutOrStdout(), "  Group:  %s\n", runner.Group)
+		fg.Fprintf(cmd.OutOrStdout(), "  Labels: %s\n", strings.Join(runner.Labels(), ", "))
+	},
+}
+
+var runnerRemoveCmd = &cobra.Command{
+	Use:   "remove <runner> [flags]",
+	Short: "Remove a self-hosted runner",
+	Long:  `The remove subcommand within the runner command forcibly removes a self-hosted runner, given by its name or ID, from a scope.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		scope, err := resolveRunnerScope()
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		runner, err := api.RemoveRunner(scope, args[0])
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		cmd.Printf("Removed runner %s from %s\n", runner.Runner.GetName(), scope)
+	},
+}
+
+var runnerTokenCmd = &cobra.Command{
+	Use:   "token [flags]",
+	Short: "Create a runner registration token",
+	Long:  `The token subcommand within the runner command creates a token to register a new self-hosted runner in a scope, and prints the command to configure the runner with it.`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		scope, err := resolveRunnerScope()
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		token, err := api.CreateRunnerRegistrationToken(scope)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		target := scope.Repo
+		if scope.Org != "" {
+			target = scope.Org
+		}
+
+		magenta.Fprintf(cmd.OutOrStdout(), "%s\n", token.GetToken())
+		fg.Fprintf(cmd.OutOrStdout(), "Expires at %s\n\n", token.GetExpiresAt().Local().Format("2006-01-02 15:04"))
+		fg.Fprintf(cmd.OutOrStdout(), "Configure the runner with:\n  ./config.sh --url https://github.com/%s --token %s\n", target, token.GetToken())
+	},
+}
+
+// resolveRunnerScope picks the organization or the repository of the runners.
+// Unlike secrets, runners do not belong to environments.
+func resolveRunnerScope() (api.Scope, error) {
+	if runnerOrg != "" {
+		if repoFlag != "" {
+			return api.Scope{}, fmt.Errorf("--org cannot be combined with --repo")
+		}
+		return api.Scope{Org: runnerOrg}, nil
+	}
+
+	repoPath, err := resolveRepoPath()
+	if err != nil {
+		return api.Scope{}, err
+	}
+
+	return api.Scope{Repo: repoPath}, nil
+}
+
+func runnerStatus(runner *api.RunnerInfo) string {
+	if runner.Runner.GetStatus() != "online" {
+		return runner.Runner.GetStatus()
+	}
+	if runner.Runner.GetBusy() {
+		return "busy"
+	}
+
+	return "idle"
+}
+
+func runnerStatusColor(status string) *color.Color {
+	switch status {
+	case "idle":
+		return color.New(color.Bold, color.FgGreen)
+	case "busy":
+		return color.New(color.Bold, color.FgYellow)
+	}
+
+	return color.New(color.Bold, color.FgRed)
+}
+
+func init() {
+	rootCmd.AddCommand(runnerCmd)
+	runnerCmd.AddCommand(runnerListCmd)
+	runnerCmd.AddCommand(runnerViewCmd)
+	runnerCmd.AddCommand(runnerRemoveCmd)
+	runnerCmd.AddCommand(runnerTokenCmd)
+
+	runnerCmd.PersistentFlags().StringVarP(&repoFlag, "repo", "R", "", "Repository in the <owner/repo> format")
+	runnerCmd.PersistentFlags().StringVarP(&runnerOrg, "org", "o", "", "Organization")
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/carolinafsilva/go-github-cli/api"
+	"github.com/google/go-github/v55/github"
+)
+
+func TestRunnerViewCmdWithNoArgs(t *testing.T) {
+	cmd := rootCmd
+
+	cmd.SetArgs([]string{"runner", "view"})
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal(expectedErrorGotNil)
+	}
+
+	expectedErr := "accepts 1 arg(s), received 0"
+	if err.Error() != expectedErr {
+		t.Errorf(expectedDifferentError, expectedErr, err.Error())
+	}
+}
+
+func TestRunnerStatus(t *testing.T) {
+	runners := map[string]*github.Runner{
+		"idle":    {Status: github.String("online"), Busy: github.Bool(false)},
+		"busy":    {Status: github.String("online"), Busy: github.Bool(true)},
+		"offline": {Status: github.String("offline"), Busy: github.Bool(false)},
+	}
+
+	for expected, runner := range runners {
+		status := runnerStatus(&api.RunnerInfo{Runner: runner})
+		if status != expected {
+			t.Errorf(expectedDifferentError, expected, status)
+		}
+	}
+}
+
+func TestResolveRunnerScopeIgnoresEnvironment(t *testing.T) {
+	runnerOrg = "acme"
+	scopeEnv = "production"
+
+	scope, err := resolveRunnerScope()
+	if err != nil {
+		t.Errorf(expectedNoError, err.Error())
+	}
+
+	if scope != (api.Scope{Org: "acme"}) {
+		t.Errorf("expected the runners of organization 'acme', but got %+v", scope)
+	}
+
+	t.Cleanup(func() {
+		runnerOrg = ""
+		scopeEnv = ""
+	})
+}
@@ -0,0 +1,297 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/carolinafsilva/go-github-cli/api"
+	"github.com/joho/godotenv"
+	"github.com/spf13/cobra"
+)
+
+var (
+	scopeEnv   string
+	scopeOrg   string
+	setBody    string
+	setEnvFile string
+	visibility string
+	setRepos   []string
+)
+
+var secretCmd = &cobra.Command{
+	Use:   "secret <command> [flags]",
+	Short: "Manage GitHub Actions secrets",
+	Long: `The secret command in GG allows you to list, set and delete the GitHub Actions secrets of a repository, one of its environments or an organization.
+
+--repo: Repository in the <owner/repo> format, defaults to the origin remote of the current directory.
+--env: Use the secrets of an environment of the repository.
+--org: Use the secrets of an organization instead of a repository.`,
+}
+
+var secretListCmd = &cobra.Command{
+	Use:   "list [flags]",
+	Short: "List secrets",
+	Long:  `The list subcommand within the secret command lists the names of the secrets in a scope and when they were last updated. Secret values cannot be read back.`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		scope, err := resolveScope()
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		secrets, err := api.ListSecrets(scope)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		if len(secrets) == 0 {
+			cmd.Println("No secrets found.")
+			return
+		}
+
+		for _, secret := range secrets {
+			magenta.Fprintf(cmd.OutOrStdout(), "%-40s ", secret.Name)
+			fg.Fprintf(cmd.OutOrStdout(), "updated %s ago", formatAge(time.Since(secret.UpdatedAt.Time)))
+			if secret.Visibility != "" {
+				fg.Fprintf(cmd.OutOrStdout(), "  %s", secret.Visibility)
+			}
+			fg.Fprintln(cmd.OutOrStdout())
+		}
+	},
+}
+
+var secretSetCmd = &cobra.Command{
+	Use:   "set [<name>] [flags]",
+	Short: "Create or update secrets",
+	Long: `The set subcommand within the secret command encrypts a value with the scope's public key and creates or updates the secret. The value is read from standard input when --body is not given.
+
+--body: Value of the secret.
+--env-file: Set every variable of a .env file as a secret instead of a single one.
+--visibility: Which repositories can use an organization secret, either all, private or selected. Defaults to private for new secrets and keeps the current visibility of existing ones.
+--repos: Repositories that can use an organization secret with the selected visibility.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runSet(cmd, args, "secret", api.SetSecrets)
+	},
+}
+
+var secretDeleteCmd = &cobra.Command{
+	Use:   "delete <name> [flags]",
+	Short: "Delete a secret",
+	Long:  `The delete subcommand within the secret command deletes a secret from a scope.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		scope, err := resolveScope()
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		if err := api.DeleteSecret(scope, args[0]); err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		cmd.Printf("Deleted secret %s from %s\n", args[0], scope)
+	},
+}
+
+var variableCmd = &cobra.Command{
+	Use:   "variable <command> [flags]",
+	Short: "Manage GitHub Actions variables",
+	Long: `The variable command in GG allows you to list, set and delete the GitHub Actions configuration variables of a repository, one of its environments or an organization.
+
+--repo: Repository in the <owner/repo> format, defaults to the origin remote of the current directory.
+--env: Use the variables of an environment of the repository.
+--org: Use the variables of an organization instead of a repository.`,
+}
+
+var variableListCmd = &cobra.Command{
+	Use:   "list [flags]",
+	Short: "List variables",
+	Long:  `The list subcommand within the variable command lists the variables in a scope with their values and when they were last updated.`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		scope, err := resolveScope()
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		variables, err := api.ListVariables(scope)
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		if len(variables) == 0 {
+			cmd.Println("No variables found.")
+			return
+		}
+
+		for _, variable := range variables {
+			magenta.Fprintf(cmd.OutOrStdout(), "%-40s ", variable.Name)
+			fg.Fprintf(cmd.OutOrStdout(), "%-30s updated %s ago", variable.Value, formatAge(time.Since(variable.GetUpdatedAt().Time)))
+			if variable.GetVisibility() != "" {
+				fg.Fprintf(cmd.OutOrStdout(), "  %s", variable.GetVisibility())
+			}
+			fg.Fprintln(cmd.OutOrStdout())
+		}
+	},
+}
+
+var variableSetCmd = &cobra.Command{
+	Use:   "set [<name>] [flags]",
+	Short: "Create or update variables",
+	Long: `The set subcommand within the variable command creates or updates a variable. The value is read from standard input when --body is not given.
+
+--body: Value of the variable.
+--env-file: Set every variable of a .env file instead of a single one.
+--visibility: Which repositories can use an organization variable, either all, private or selected. Defaults to private for new variables and keeps the current visibility of existing ones.
+--repos: Repositories that can use an organization variable with the selected visibility.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runSet(cmd, args, "variable", api.SetVariables)
+	},
+}
+
+var variableDeleteCmd = &cobra.Command{
+	Use:   "delete <name> [flags]",
+	Short: "Delete a variable",
+	Long:  `The delete subcommand within the variable command deletes a variable from a scope.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		scope, err := resolveScope()
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		if err := api.DeleteVariable(scope, args[0]); err != nil {
+			cmd.Println(err)
+			return
+		}
+
+		cmd.Printf("Deleted variable %s from %s\n", args[0], scope)
+	},
+}
+
+func resolveScope() (api.Scope, error) {
+	if scopeOrg != "" {
+		if repoFlag != "" || scopeEnv != "" {
+			return api.Scope{}, fmt.Errorf("--org cannot be combined with --repo or --env")
+		}
+		return api.Scope{Org: scopeOrg}, nil
+	}
+
+	repoPath, err := resolveRepoPath()
+	if err != nil {
+		return api.Scope{}, err
+	}
+
+	return api.Scope{Repo: repoPath, Env: scopeEnv}, nil
+}
+
+func runSet(cmd *cobra.Command, args []string, kind string, set func(api.Scope, map[string]string, api.OrgVisibility) error) {
+	values, err := readValues(cmd.InOrStdin(), args)
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+
+	orgVisibility := api.OrgVisibility{Repos: setRepos}
+	if cmd.Flags().Changed("visibility") {
+		if visibility != "all" && visibility != "private" && visibility != "selected" {
+			cmd.Printf("invalid visibility '%s', use all, private or selected\n", visibility)
+			return
+		}
+		orgVisibility.Visibility = visibility
+	}
+	if (orgVisibility.Visibility == "selected") != (len(setRepos) > 0) {
+		cmd.Println("--repos is required with --visibility selected, and only allowed with it")
+		return
+	}
+
+	scope, err := resolveScope()
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+
+	if err := set(scope, values, orgVisibility); err != nil {
+		cmd.Println(err)
+		return
+	}
+
+	for name := range values {
+		cmd.Printf("Set %s %s for %s\n", kind, name, scope)
+	}
+}
+
+// readValues returns the name and value given on the command line, or every
+// entry of --env-file.
+func readValues(stdin io.Reader, args []string) (map[string]string, error) {
+	if setEnvFile != "" {
+		if len(args) > 0 || setBody != "" {
+			return nil, fmt.Errorf("--env-file cannot be combined with a name or --body")
+		}
+
+		values, err := godotenv.Read(setEnvFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read env file '%s': %s", setEnvFile, err)
+		}
+		if len(values) == 0 {
+			return nil, fmt.Errorf("env file '%s' is empty", setEnvFile)
+		}
+
+		return values, nil
+	}
+
+	if len(args) == 0 {
+		return nil, fmt.Errorf("a name or --env-file is required")
+	}
+
+	value := setBody
+	if value == "" {
+		content, err := io.ReadAll(stdin)
+		if err != nil {
+			return nil, fmt.Errorf("could not read value from standard input: %s", err)
+		}
+		value = strings.TrimRight(string(content), "\r\n")
+	}
+
+	if value == "" {
+		return nil, fmt.Errorf("value cannot be empty")
+	}
+
+	return map[string]string{args[0]: value}, nil
+}
+
+func init() {
+	rootCmd.AddCommand(secretCmd)
+	secretCmd.AddCommand(secretListCmd)
+	secretCmd.AddCommand(secretSetCmd)
+	secretCmd.AddCommand(secretDeleteCmd)
+
+	rootCmd.AddCommand(variableCmd)
+	variableCmd.AddCommand(variableListCmd)
+	variableCmd.AddCommand(variableSetCmd)
+	variableCmd.AddCommand(variableDeleteCmd)
+
+	for _, cmd := range []*cobra.Command{secretCmd, variableCmd} {
+		cmd.PersistentFlags().StringVarP(&repoFlag, "repo", "R", "", "Repository in the <owner/repo> format")
+		cmd.PersistentFlags().StringVarP(&scopeEnv, "env", "e", "", "Environment of the repository")
+		cmd.PersistentFlags().StringVarP(&scopeOrg, "org", "o", "", "Organization")
+	}
+
+	for _, cmd := range []*cobra.Command{secretSetCmd, variableSetCmd} {
+		cmd.Flags().StringVarP(&setBody, "body", "b", "", "Value to set")
+		cmd.Flags().StringVarP(&setEnvFile, "env-file", "f", "", "Set every entry of a .env file")
+		cmd.Flags().StringVarP(&visibility, "visibility", "v", "private", "Visibility of an organization secret or variable")
+		cmd.Flags().StringSliceVar(&setRepos, "repos", nil, "Repositories that can use a secret or variable with the selected visibility")
+	}
+}
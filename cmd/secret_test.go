@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSecretSetCmdWithOrgAndRepo(t *testing.T) {
+	cmd := rootCmd
+
+	var output bytes.Buffer
+	cmd.SetOut(&output)
+
+	cmd.SetArgs([]string{"secret", "set", "API_TOKEN", "--body", "hunter2", "--org", "acme", "--repo", "acme/api"})
+
+	err := cmd.Execute()
+	if err != nil {
+		t.Errorf(expectedNoError, err)
+	}
+
+	expectedMsg := "--org cannot be combined with --repo or --env\n"
+	if output.String() != expectedMsg {
+		t.Errorf(expectedDifferentError, expectedMsg, output.String())
+	}
+
+	t.Cleanup(func() {
+		cmd.SetOut(nil)
+		repoFlag = ""
+		scopeOrg = ""
+		setBody = ""
+	})
+}
+
+func TestSecretSetCmdWithSelectedVisibilityAndNoRepos(t *testing.T) {
+	cmd := rootCmd
+
+	var output bytes.Buffer
+	cmd.SetOut(&output)
+
+	cmd.SetArgs([]string{"secret", "set", "API_TOKEN", "--body", "hunter2", "--org", "acme", "--visibility", "selected"})
+
+	err := cmd.Execute()
+	if err != nil {
+		t.Errorf(expectedNoError, err)
+	}
+
+	expectedMsg := "--repos is required with --visibility selected, and only allowed with it\n"
+	if output.String() != expectedMsg {
+		t.Errorf(expectedDifferentError, expectedMsg, output.String())
+	}
+
+	t.Cleanup(func() {
+		cmd.SetOut(nil)
+		scopeOrg = ""
+		setBody = ""
+		visibility = "private"
+		secretSetCmd.Flags().Lookup("visibility").Changed = false
+	})
+}
+
+func TestVariableDeleteCmdWithNoArgs(t *testing.T) {
+	cmd := rootCmd
+
+	cmd.SetArgs([]string{"variable", "delete"})
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal(expectedErrorGotNil)
+	}
+
+	expectedErr := "accepts 1 arg(s), received 0"
+	if err.Error() != expectedErr {
+		t.Errorf(expectedDifferentError, expectedErr, err.Error())
+	}
+}
+
+func TestReadValues(t *testing.T) {
+	values, err := readValues(strings.NewReader("hunter2\n"), []string{"API_TOKEN"})
+	if err != nil {
+		t.Fatalf(expectedNoError, err)
+	}
+	if values["API_TOKEN"] != "hunter2" {
+		t.Errorf(expectedDifferentError, "hunter2", values["API_TOKEN"])
+	}
+
+	_, err = readValues(strings.NewReader(""), nil)
+
+	expectedErr := "a name or --env-file is required"
+	if err == nil {
+		t.Error(expectedErrorGotNil)
+	} else if err.Error() != expectedErr {
+		t.Errorf(expectedDifferentError, expectedErr, err.Error())
+	}
+}
+
+func TestReadValuesFromEnvFile(t *testing.T) {
+	envFile := filepath.Join(t.TempDir(), ".env")
+	os.WriteFile(envFile, []byte("# deploy settings\nAPI_TOKEN=hunter2\nREGION=\"eu-west-1\"\n"), 0600)
+
+	setEnvFile = envFile
+	t.Cleanup(func() {
+		setEnvFile = ""
+	})
+
+	values, err := readValues(strings.NewReader(""), nil)
+	if err != nil {
+		t.Fatalf(expectedNoError, err)
+	}
+
+	if len(values) != 2 || values["API_TOKEN"] != "hunter2" || values["REGION"] != "eu-west-1" {
+		t.Errorf(expectedDifferentError, "API_TOKEN=hunter2 REGION=eu-west-1", values)
+	}
+
+	_, err = readValues(strings.NewReader(""), []string{"API_TOKEN"})
+
+	expectedErr := "--env-file cannot be combined with a name or --body"
+	if err == nil {
+		t.Error(expectedErrorGotNil)
+	} else if err.Error() != expectedErr {
+		t.Errorf(expectedDifferentError, expectedErr, err.Error())
+	}
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	pollIntervalMin = 5 * time.Second
+	pollIntervalMax = 60 * time.Second
+)
+
+func nextPollInterval(current time.Duration, changed bool) time.Duration {
+	if changed {
+		return pollIntervalMin
+	}
+
+	next := current * 3 / 2
+	if next > pollIntervalMax {
+		next = pollIntervalMax
+	}
+
+	return next
+}
+
+type redrawer struct {
+	out   io.Writer
+	lines int
+}
+
+func (r *redrawer) draw(content string) {
+	if r.lines > 0 {
+		// move the cursor back to the start of the previous frame and clear it
+		fmt.Fprintf(r.out, "\033[%dA\033[J", r.lines)
+	}
+
+	fmt.Fprint(r.out, content)
+	r.lines = strings.Count(content, "\n")
+}
+
+// watch redraws the frame returned by poll in place until poll reports it is done,
+// polling less often while nothing changes. Frames show ticking durations, so
+// poll also returns a state without them to tell whether anything changed.
+func watch(out io.Writer, poll func() (string, string, bool, error)) error {
+	screen := &redrawer{out: out}
+	interval := pollIntervalMin
+	previous := ""
+
+	for {
+		frame, state, done, err := poll()
+		if err != nil {
+			return err
+		}
+
+		screen.draw(frame)
+		if done {
+			return nil
+		}
+
+		interval = nextPollInterval(interval, state != previous)
+		previous = state
+		time.Sleep(interval)
+	}
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestNextPollIntervalBacksOff(t *testing.T) {
+	interval := nextPollInterval(pollIntervalMin, false)
+	if interval <= pollIntervalMin {
+		t.Errorf("expected interval to grow above %s, got %s", pollIntervalMin, interval)
+	}
+
+	interval = nextPollInterval(pollIntervalMax, false)
+	if interval != pollIntervalMax {
+		t.Errorf(expectedDifferentError, pollIntervalMax, interval)
+	}
+
+	interval = nextPollInterval(30*time.Second, true)
+	if interval != pollIntervalMin {
+		t.Errorf(expectedDifferentError, pollIntervalMin, interval)
+	}
+}
+
+func TestRedrawerClearsPreviousFrame(t *testing.T) {
+	var output bytes.Buffer
+	screen := &redrawer{out: &output}
+
+	screen.draw("a\nb\n")
+	screen.draw("c\n")
+
+	expectedOutput := "a\nb\n\033[2A\033[Jc\n"
+	if output.String() != expectedOutput {
+		t.Errorf(expectedDifferentError, expectedOutput, output.String())
+	}
+}
+
+func TestWatchStopsWhenDone(t *testing.T) {
+	var output bytes.Buffer
+
+	err := watch(&output, func() (string, string, bool, error) {
+		return "done\n", "", true, nil
+	})
+	if err != nil {
+		t.Errorf(expectedNoError, err)
+	}
+
+	if output.String() != "done\n" {
+		t.Errorf(expectedDifferentError, "done\n", output.String())
+	}
+}
@@ -4,13 +4,15 @@ go 1.21.1
 
 require golang.org/x/oauth2 v0.12.0
 
+require gopkg.in/yaml.v3 v3.0.1 // direct
+
 require (
 	github.com/ProtonMail/go-crypto v0.0.0-20230217124315-7d5c6f04bbb8 // indirect
 	github.com/cloudflare/circl v1.3.3 // indirect
 	github.com/fatih/color v1.15.0 // direct
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.17 // indirect
-	golang.org/x/crypto v0.14.0 // indirect
+	golang.org/x/crypto v0.14.0 // direct
 	golang.org/x/sys v0.13.0 // indirect
 )
 